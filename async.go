@@ -0,0 +1,81 @@
+package retry
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/Rican7/retry/strategy"
+)
+
+// Operation is a handle to a retry process that is running asynchronously.
+type Operation struct {
+	cancel   context.CancelFunc
+	done     chan struct{}
+	err      error
+	attempts uint64
+}
+
+// Go takes an action and performs it, repetitively, until successful, in a new
+// goroutine. It returns an Operation that may be used to wait on, inspect, or
+// cancel the retry process.
+//
+// Optionally, strategies may be passed that assess whether or not an attempt
+// should be made.
+//
+// Go is equivalent to calling Go on a Policy created with the given strategies.
+func Go(ctx context.Context, action ContextAction, strategies ...strategy.Strategy) *Operation {
+	return NewPolicy(WithStrategies(strategies...)).Go(ctx, action)
+}
+
+// Go takes an action and performs it, repetitively, until successful or until
+// the Policy halts the retrying process, in a new goroutine. It returns an
+// Operation that may be used to wait on, inspect, or cancel the retry process.
+//
+// The context passed to the action is cancelled when the given context is done,
+// when the Operation is cancelled, or once the retry process completes.
+func (p *Policy) Go(ctx context.Context, action ContextAction) *Operation {
+	ctx, cancel := context.WithCancel(ctx)
+
+	operation := &Operation{
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	policy := p.With(WithHooks(func(attempt Attempt) {
+		atomic.StoreUint64(&operation.attempts, uint64(attempt.Number))
+	}))
+
+	go func() {
+		defer close(operation.done)
+		defer cancel()
+
+		operation.err = policy.DoContext(ctx, func(attempt uint) error {
+			return action(ctx, attempt)
+		})
+	}()
+
+	return operation
+}
+
+// Wait waits for the retry process to complete and returns its resulting error.
+func (o *Operation) Wait() error {
+	<-o.done
+
+	return o.err
+}
+
+// Done returns a channel that's closed when the retry process completes.
+func (o *Operation) Done() <-chan struct{} {
+	return o.done
+}
+
+// Attempts returns the number of attempts that have been made so far.
+func (o *Operation) Attempts() uint {
+	return uint(atomic.LoadUint64(&o.attempts))
+}
+
+// Cancel cancels the retry process, interrupting any wait between attempts.
+// Cancel does not wait for the retry process to stop; use Wait or Done for that.
+func (o *Operation) Cancel() {
+	o.cancel()
+}
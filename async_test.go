@@ -0,0 +1,214 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/Rican7/retry/backoff"
+	"github.com/Rican7/retry/strategy"
+)
+
+// assertNoGoroutineLeak fails the test if the number of running goroutines
+// doesn't return to the given count within a reasonable amount of time.
+func assertNoGoroutineLeak(t *testing.T, expected int) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+
+	for runtime.NumGoroutine() > expected {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d goroutines, but %d are running", expected, runtime.NumGoroutine())
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestGo(t *testing.T) {
+	const errorUntilAttemptNumber = 3
+
+	goroutines := runtime.NumGoroutine()
+
+	operation := Go(context.Background(), func(ctx context.Context, attempt uint) error {
+		if errorUntilAttemptNumber == attempt {
+			return nil
+		}
+
+		return errors.New("erroring")
+	})
+
+	if err := operation.Wait(); err != nil {
+		t.Errorf("expected a nil error, received %q instead", err)
+	}
+
+	select {
+	case <-operation.Done():
+	default:
+		t.Error("expected the operation to be done")
+	}
+
+	if attempts := operation.Attempts(); attempts != errorUntilAttemptNumber {
+		t.Errorf("expected %d attempts to be made, but %d were made instead", errorUntilAttemptNumber, attempts)
+	}
+
+	assertNoGoroutineLeak(t, goroutines)
+}
+
+func TestGoWithStrategies(t *testing.T) {
+	const attemptLimit = 4
+
+	errFailed := errors.New("failed")
+
+	operation := Go(context.Background(), func(ctx context.Context, attempt uint) error {
+		return errFailed
+	}, strategy.Limit(attemptLimit))
+
+	if err := operation.Wait(); err != errFailed {
+		t.Errorf("expected the action's error, received %q instead", err)
+	}
+
+	if attempts := operation.Attempts(); attempts != attemptLimit {
+		t.Errorf("expected %d attempts to be made, but %d were made instead", attemptLimit, attempts)
+	}
+}
+
+func TestGoCancel(t *testing.T) {
+	goroutines := runtime.NumGoroutine()
+
+	started := make(chan struct{})
+
+	operation := Go(context.Background(), func(ctx context.Context, attempt uint) error {
+		if attempt == 1 {
+			close(started)
+		}
+
+		<-ctx.Done()
+
+		return ctx.Err()
+	})
+
+	<-started
+	operation.Cancel()
+
+	select {
+	case <-operation.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected the operation to be done after being cancelled")
+	}
+
+	if err := operation.Wait(); err != context.Canceled {
+		t.Errorf("expected a context canceled error, received %q instead", err)
+	}
+
+	if attempts := operation.Attempts(); attempts != 1 {
+		t.Errorf("expected 1 attempt to be made, but %d were made instead", attempts)
+	}
+
+	assertNoGoroutineLeak(t, goroutines)
+}
+
+func TestGoCancelWhileWaiting(t *testing.T) {
+	goroutines := runtime.NumGoroutine()
+
+	strategies := map[string]strategy.Strategy{
+		"wait":    strategy.Wait(time.Hour),
+		"backoff": strategy.Backoff(backoff.Incremental(time.Hour, 0)),
+	}
+
+	for name, waiting := range strategies {
+		attempted := make(chan struct{})
+
+		operation := Go(context.Background(), func(ctx context.Context, attempt uint) error {
+			close(attempted)
+
+			return errors.New("erroring")
+		}, waiting)
+
+		<-attempted
+		operation.Cancel()
+
+		select {
+		case <-operation.Done():
+		case <-time.After(time.Second):
+			t.Fatalf("expected the %s operation to be done after being cancelled", name)
+		}
+
+		if err := operation.Wait(); err != context.Canceled {
+			t.Errorf("expected a context canceled error, received %q instead", err)
+		}
+	}
+
+	assertNoGoroutineLeak(t, goroutines)
+}
+
+func TestGoCancelWhileRateLimited(t *testing.T) {
+	goroutines := runtime.NumGoroutine()
+
+	attempted := make(chan struct{})
+
+	// Only the first attempt is allowed before the limiter blocks for an hour
+	limiter := strategy.NewTokenBucket(1, time.Hour, nil)
+
+	operation := Go(context.Background(), func(ctx context.Context, attempt uint) error {
+		close(attempted)
+
+		return errors.New("erroring")
+	}, strategy.RateLimit(limiter))
+
+	<-attempted
+	operation.Cancel()
+
+	select {
+	case <-operation.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected the operation to be done after being cancelled")
+	}
+
+	if err := operation.Wait(); err != context.Canceled {
+		t.Errorf("expected a context canceled error, received %q instead", err)
+	}
+
+	assertNoGoroutineLeak(t, goroutines)
+}
+
+func TestGoParentContextCancelled(t *testing.T) {
+	goroutines := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	operation := Go(ctx, func(ctx context.Context, attempt uint) error {
+		return errors.New("erroring")
+	}, strategy.Wait(time.Millisecond))
+
+	cancel()
+
+	if err := operation.Wait(); err != context.Canceled {
+		t.Errorf("expected a context canceled error, received %q instead", err)
+	}
+
+	assertNoGoroutineLeak(t, goroutines)
+}
+
+func TestPolicyGo(t *testing.T) {
+	var hookCalls int
+
+	policy := NewPolicy(
+		WithStrategies(strategy.Limit(2)),
+		WithHooks(func(attempt Attempt) {
+			hookCalls++
+		}),
+	)
+
+	operation := policy.Go(context.Background(), func(ctx context.Context, attempt uint) error {
+		return errors.New("erroring")
+	})
+
+	operation.Wait()
+
+	if hookCalls != 2 {
+		t.Errorf("expected the policy's hooks to be called 2 times, but they were called %d times", hookCalls)
+	}
+}
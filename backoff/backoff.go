@@ -16,42 +16,71 @@ type Algorithm func(attempt uint) time.Duration
 // Incremental creates a Algorithm that increments the initial duration
 // by the given increment for each attempt.
 func Incremental(initial, increment time.Duration) Algorithm {
-	return func(attempt uint) time.Duration {
+	return newAlgorithm(func(attempt uint) time.Duration {
 		return initial + (increment * time.Duration(attempt))
-	}
+	}, "Incremental", initial, increment)
 }
 
 // Linear creates a Algorithm that linearly multiplies the factor
 // duration by the attempt number for each attempt.
 func Linear(factor time.Duration) Algorithm {
-	return func(attempt uint) time.Duration {
+	return newAlgorithm(func(attempt uint) time.Duration {
 		return (factor * time.Duration(attempt))
-	}
+	}, "Linear", factor)
 }
 
 // Exponential creates a Algorithm that multiplies the factor duration by
 // an exponentially increasing factor for each attempt, where the factor is
 // calculated as the given base raised to the attempt number.
 func Exponential(factor time.Duration, base float64) Algorithm {
-	return func(attempt uint) time.Duration {
+	return newAlgorithm(func(attempt uint) time.Duration {
 		return (factor * time.Duration(math.Pow(base, float64(attempt))))
-	}
+	}, "Exponential", factor, base)
 }
 
 // BinaryExponential creates a Algorithm that multiplies the factor
 // duration by an exponentially increasing factor for each attempt, where the
 // factor is calculated as `2` raised to the attempt number (2^attempt).
 func BinaryExponential(factor time.Duration) Algorithm {
-	return Exponential(factor, 2)
+	return newAlgorithm(Exponential(factor, 2), "BinaryExponential", factor)
+}
+
+// Polynomial creates a Algorithm that multiplies the factor duration by a
+// polynomially increasing factor for each attempt, where the factor is
+// calculated as the attempt number raised to the given exponent. For example,
+// an exponent of `2` results in quadratic growth. Durations are limited to the
+// representable durations, rather than overflowing.
+//
+// As zero can't be raised to a negative exponent, a non-positive exponent
+// results in the factor duration itself for attempt `0`.
+func Polynomial(factor time.Duration, exponent float64) Algorithm {
+	return newAlgorithm(func(attempt uint) time.Duration {
+		if attempt == 0 && !(exponent > 0) {
+			return factor
+		}
+
+		return scaleDuration(factor, math.Pow(float64(attempt), exponent))
+	}, "Polynomial", factor, exponent)
+}
+
+// Logarithmic creates a Algorithm that multiplies the factor duration by a
+// logarithmically increasing factor for each attempt, where the factor is
+// calculated as the binary logarithm of the attempt number plus one
+// (log2(attempt+1)). Durations are limited to the longest representable
+// duration, rather than overflowing.
+func Logarithmic(factor time.Duration) Algorithm {
+	return newAlgorithm(func(attempt uint) time.Duration {
+		return scaleDuration(factor, math.Log2(float64(attempt)+1))
+	}, "Logarithmic", factor)
 }
 
 // Fibonacci creates a Algorithm that multiplies the factor duration by
 // an increasing factor for each attempt, where the factor is the Nth number in
 // the Fibonacci sequence.
 func Fibonacci(factor time.Duration) Algorithm {
-	return func(attempt uint) time.Duration {
+	return newAlgorithm(func(attempt uint) time.Duration {
 		return (factor * time.Duration(fibonacciNumber(attempt)))
-	}
+	}, "Fibonacci", factor)
 }
 
 // fibonacciNumber calculates the Fibonacci sequence number for the given
@@ -63,3 +92,21 @@ func fibonacciNumber(n uint) uint {
 
 	return fibonacciNumber(n-1) + fibonacciNumber(n-2)
 }
+
+// scaleDuration multiplies the given duration by the given multiplier, limiting
+// the result to the representable durations, rather than overflowing. An
+// undefined (NaN) result is a zero duration.
+func scaleDuration(duration time.Duration, multiplier float64) time.Duration {
+	scaled := float64(duration) * multiplier
+
+	switch {
+	case math.IsNaN(scaled):
+		return 0
+	case scaled >= float64(maxDuration):
+		return maxDuration
+	case scaled <= float64(minDuration):
+		return minDuration
+	}
+
+	return time.Duration(scaled)
+}
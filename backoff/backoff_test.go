@@ -84,6 +84,124 @@ func TestFibonacci(t *testing.T) {
 	}
 }
 
+func TestPolynomial(t *testing.T) {
+	const duration = time.Millisecond
+	const exponent = 2
+
+	algorithm := Polynomial(duration, exponent)
+
+	for i := uint(0); i < 10; i++ {
+		result := algorithm(i)
+		expected := time.Duration(math.Pow(float64(i), exponent)) * duration
+
+		if result != expected {
+			t.Errorf("algorithm expected to return a %s duration, but received %s instead", expected, result)
+		}
+	}
+}
+
+func TestPolynomialOverflow(t *testing.T) {
+	algorithm := Polynomial(time.Hour, 3)
+
+	for _, attempt := range []uint{1 << 10, 1 << 20, math.MaxUint32, math.MaxUint} {
+		if result := algorithm(attempt); result != math.MaxInt64 {
+			t.Errorf("algorithm expected to return a %s duration for attempt %d, but received %s instead", time.Duration(math.MaxInt64), attempt, result)
+		}
+	}
+
+	for i := uint(1); i < 10000; i++ {
+		if algorithm(i) < algorithm(i-1) {
+			t.Fatalf("algorithm expected to never decrease, but did at attempt %d", i)
+		}
+	}
+}
+
+func TestPolynomialNonPositiveExponent(t *testing.T) {
+	const duration = time.Second
+
+	for _, factor := range []time.Duration{0, duration, -duration} {
+		for _, exponent := range []float64{0, -1, -2.5} {
+			algorithm := Polynomial(factor, exponent)
+
+			if result := algorithm(0); result != factor {
+				t.Errorf("algorithm expected to return a %s duration for attempt 0, but received %s instead", factor, result)
+			}
+
+			for i := uint(1); i < 10; i++ {
+				result := algorithm(i)
+				expected := time.Duration(math.Pow(float64(i), exponent) * float64(factor))
+
+				if result != expected {
+					t.Errorf("algorithm expected to return a %s duration, but received %s instead", expected, result)
+				}
+			}
+		}
+	}
+}
+
+func TestScaleDuration(t *testing.T) {
+	cases := []struct {
+		duration   time.Duration
+		multiplier float64
+		expected   time.Duration
+	}{
+		{time.Second, 1.5, 1500 * time.Millisecond},
+		{time.Second, -1.5, -1500 * time.Millisecond},
+		{math.MaxInt64 / 2, 3, math.MaxInt64},
+		{math.MaxInt64 / 2, -3, math.MinInt64},
+		{time.Second, math.Inf(1), math.MaxInt64},
+		{time.Second, math.Inf(-1), math.MinInt64},
+		{-time.Second, math.Inf(1), math.MinInt64},
+		{0, math.Inf(1), 0},
+		{time.Second, math.NaN(), 0},
+	}
+
+	for _, c := range cases {
+		if result := scaleDuration(c.duration, c.multiplier); result != c.expected {
+			t.Errorf("expected %s scaled by %v to be %s, received %s instead", c.duration, c.multiplier, c.expected, result)
+		}
+	}
+}
+
+func TestLogarithmic(t *testing.T) {
+	const duration = time.Second
+
+	algorithm := Logarithmic(duration)
+
+	for i := uint(0); i < 10; i++ {
+		result := algorithm(i)
+		expected := time.Duration(math.Log2(float64(i)+1) * float64(duration))
+
+		if result != expected {
+			t.Errorf("algorithm expected to return a %s duration, but received %s instead", expected, result)
+		}
+	}
+
+	for attempt, expected := range map[uint]time.Duration{0: 0, 1: duration, 3: 2 * duration, 7: 3 * duration} {
+		if result := algorithm(attempt); result != expected {
+			t.Errorf("algorithm expected to return a %s duration, but received %s instead", expected, result)
+		}
+	}
+}
+
+func TestLogarithmicOverflow(t *testing.T) {
+	algorithm := Logarithmic(math.MaxInt64 / 2)
+
+	for _, attempt := range []uint{4, 1 << 20, math.MaxUint32, math.MaxUint} {
+		if result := algorithm(attempt); result != math.MaxInt64 {
+			t.Errorf("algorithm expected to return a %s duration for attempt %d, but received %s instead", time.Duration(math.MaxInt64), attempt, result)
+		}
+	}
+
+	algorithm = Logarithmic(time.Second)
+
+	for i := uint(1); i < 10000; i++ {
+		if algorithm(i) < algorithm(i-1) {
+			t.Fatalf("algorithm expected to never decrease, but did at attempt %d", i)
+		}
+	}
+}
+
 func TestFibonacciNumber(t *testing.T) {
 	// Fibonacci sequence
 	expectedSequence := []uint{0, 1, 1, 2, 3, 5, 8, 13, 21, 34, 55, 89, 144, 233}
@@ -181,3 +299,37 @@ func ExampleFibonacci() {
 	// #4 attempt: 45ms
 	// #5 attempt: 75ms
 }
+
+func ExamplePolynomial() {
+	algorithm := Polynomial(15*time.Millisecond, 2)
+
+	for i := uint(1); i <= 5; i++ {
+		duration := algorithm(i)
+
+		fmt.Printf("#%d attempt: %s\n", i, duration)
+	}
+
+	// Output:
+	// #1 attempt: 15ms
+	// #2 attempt: 60ms
+	// #3 attempt: 135ms
+	// #4 attempt: 240ms
+	// #5 attempt: 375ms
+}
+
+func ExampleLogarithmic() {
+	algorithm := Logarithmic(15 * time.Millisecond)
+
+	for i := uint(1); i <= 5; i++ {
+		duration := algorithm(i)
+
+		fmt.Printf("#%d attempt: %s\n", i, duration)
+	}
+
+	// Output:
+	// #1 attempt: 15ms
+	// #2 attempt: 23.774437ms
+	// #3 attempt: 30ms
+	// #4 attempt: 34.828921ms
+	// #5 attempt: 38.774437ms
+}
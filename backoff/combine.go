@@ -0,0 +1,119 @@
+package backoff
+
+import (
+	"math"
+	"time"
+
+	"github.com/Rican7/retry/internal/describe"
+)
+
+// maxDuration is the longest representable time.Duration.
+const maxDuration = time.Duration(math.MaxInt64)
+
+// minDuration is the most negative representable time.Duration.
+const minDuration = time.Duration(math.MinInt64)
+
+// Add creates an Algorithm that returns the sum of the durations returned by
+// the given algorithms. The sum is limited to the longest representable
+// duration, rather than overflowing.
+func Add(algorithms ...Algorithm) Algorithm {
+	return newAlgorithm(func(attempt uint) time.Duration {
+		var sum time.Duration
+
+		for _, algorithm := range algorithms {
+			duration := algorithm(attempt)
+
+			if duration > 0 && sum > maxDuration-duration {
+				return maxDuration
+			}
+
+			sum += duration
+		}
+
+		return sum
+	}, "Add", describe.Args(algorithms)...)
+}
+
+// Max creates an Algorithm that returns the longest of the durations returned
+// by the given algorithms.
+func Max(algorithms ...Algorithm) Algorithm {
+	return newAlgorithm(func(attempt uint) time.Duration {
+		var max time.Duration
+
+		for i, algorithm := range algorithms {
+			if duration := algorithm(attempt); i == 0 || duration > max {
+				max = duration
+			}
+		}
+
+		return max
+	}, "Max", describe.Args(algorithms)...)
+}
+
+// Min creates an Algorithm that returns the shortest of the durations returned
+// by the given algorithms. For example, an algorithm may be capped at a maximum
+// duration with `Min(algorithm, Incremental(max, 0))`.
+func Min(algorithms ...Algorithm) Algorithm {
+	return newAlgorithm(func(attempt uint) time.Duration {
+		var min time.Duration
+
+		for i, algorithm := range algorithms {
+			if duration := algorithm(attempt); i == 0 || duration < min {
+				min = duration
+			}
+		}
+
+		return min
+	}, "Min", describe.Args(algorithms)...)
+}
+
+// Scale creates an Algorithm that multiplies the durations returned by the
+// given algorithm by the given factor. The result is limited to the longest
+// representable duration, rather than overflowing.
+func Scale(algorithm Algorithm, factor float64) Algorithm {
+	return newAlgorithm(func(attempt uint) time.Duration {
+		return scaleDuration(algorithm(attempt), factor)
+	}, "Scale", algorithm, factor)
+}
+
+// Shift creates an Algorithm that calls the given algorithm with the attempt
+// number shifted by the given number of attempts. A negative shift makes the
+// given algorithm start over, as it's called with an attempt number of `0` for
+// the attempts that would otherwise be negative.
+func Shift(algorithm Algorithm, attempts int) Algorithm {
+	return newAlgorithm(func(attempt uint) time.Duration {
+		if attempts < 0 && attempt < uint(-attempts) {
+			return algorithm(0)
+		}
+
+		return algorithm(uint(int(attempt) + attempts))
+	}, "Shift", algorithm, attempts)
+}
+
+// Piece defines a piece of a Piecewise Algorithm.
+type Piece struct {
+	// UpTo is the last attempt number, inclusive, that the piece's Algorithm
+	// is used for.
+	UpTo uint
+
+	// Algorithm is the Algorithm used for the piece's attempts.
+	Algorithm Algorithm
+}
+
+// Piecewise creates an Algorithm that uses the Algorithm of the first of the
+// given pieces whose attempts include the attempt number. The last piece is
+// used for all attempts after the others, regardless of its attempts.
+//
+// The pieces' algorithms are called with the unmodified attempt number. To
+// have an algorithm start over with its piece, use Shift.
+func Piecewise(pieces ...Piece) Algorithm {
+	return newAlgorithm(func(attempt uint) time.Duration {
+		for i, piece := range pieces {
+			if attempt <= piece.UpTo || i == len(pieces)-1 {
+				return piece.Algorithm(attempt)
+			}
+		}
+
+		return 0
+	}, "Piecewise", describe.Args(pieces)...)
+}
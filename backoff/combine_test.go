@@ -0,0 +1,141 @@
+package backoff
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAdd(t *testing.T) {
+	const duration = time.Millisecond
+
+	algorithm := Add(Linear(duration), Incremental(duration, 0))
+
+	for i := uint(0); i < 10; i++ {
+		result := algorithm(i)
+		expected := (time.Duration(i) * duration) + duration
+
+		if result != expected {
+			t.Errorf("algorithm expected to return a %s duration, but received %s instead", expected, result)
+		}
+	}
+
+	if result := Add()(1); result != 0 {
+		t.Errorf("algorithm expected to return a 0 duration, but received %s instead", result)
+	}
+}
+
+func TestAddOverflow(t *testing.T) {
+	algorithm := Add(Incremental(math.MaxInt64, 0), Incremental(time.Second, 0))
+
+	if result := algorithm(1); result != math.MaxInt64 {
+		t.Errorf("algorithm expected to return a %s duration, but received %s instead", time.Duration(math.MaxInt64), result)
+	}
+}
+
+func TestMax(t *testing.T) {
+	const duration = time.Millisecond
+
+	algorithm := Max(Linear(duration), Incremental(5*duration, 0))
+
+	for i := uint(0); i < 10; i++ {
+		result := algorithm(i)
+		expected := time.Duration(math.Max(float64(i), 5)) * duration
+
+		if result != expected {
+			t.Errorf("algorithm expected to return a %s duration, but received %s instead", expected, result)
+		}
+	}
+}
+
+func TestMin(t *testing.T) {
+	const duration = time.Millisecond
+
+	algorithm := Min(BinaryExponential(duration), Incremental(30*duration, 0))
+
+	for i := uint(0); i < 10; i++ {
+		result := algorithm(i)
+		expected := time.Duration(math.Min(math.Pow(2, float64(i)), 30)) * duration
+
+		if result != expected {
+			t.Errorf("algorithm expected to return a %s duration, but received %s instead", expected, result)
+		}
+	}
+}
+
+func TestScale(t *testing.T) {
+	const duration = time.Millisecond
+
+	algorithm := Scale(Linear(duration), 1.5)
+
+	for i := uint(0); i < 10; i++ {
+		result := algorithm(i)
+		expected := time.Duration(float64(i) * 1.5 * float64(duration))
+
+		if result != expected {
+			t.Errorf("algorithm expected to return a %s duration, but received %s instead", expected, result)
+		}
+	}
+
+	if result := Scale(Incremental(math.MaxInt64/2, 0), 3)(1); result != math.MaxInt64 {
+		t.Errorf("algorithm expected to return a %s duration, but received %s instead", time.Duration(math.MaxInt64), result)
+	}
+}
+
+func TestShift(t *testing.T) {
+	const duration = time.Millisecond
+
+	algorithm := Shift(Linear(duration), 2)
+
+	for i := uint(0); i < 10; i++ {
+		result := algorithm(i)
+		expected := time.Duration(i+2) * duration
+
+		if result != expected {
+			t.Errorf("algorithm expected to return a %s duration, but received %s instead", expected, result)
+		}
+	}
+}
+
+func TestShiftNegative(t *testing.T) {
+	const duration = time.Millisecond
+
+	algorithm := Shift(Linear(duration), -3)
+
+	for i := uint(0); i < 10; i++ {
+		result := algorithm(i)
+		expected := time.Duration(math.Max(float64(i)-3, 0)) * duration
+
+		if result != expected {
+			t.Errorf("algorithm expected to return a %s duration, but received %s instead", expected, result)
+		}
+	}
+}
+
+func TestPiecewise(t *testing.T) {
+	const duration = time.Millisecond
+
+	// Linear for 3 attempts, then exponential, capped at 30 times the duration
+	algorithm := Min(
+		Piecewise(
+			Piece{UpTo: 3, Algorithm: Linear(duration)},
+			Piece{Algorithm: Shift(BinaryExponential(duration), -2)},
+		),
+		Incremental(30*duration, 0),
+	)
+
+	expected := []time.Duration{0, 1, 2, 3, 4, 8, 16, 30, 30, 30}
+
+	for i, expected := range expected {
+		result := algorithm(uint(i))
+		expected *= duration
+
+		if result != expected {
+			t.Errorf("algorithm expected to return a %s duration, but received %s instead", expected, result)
+		}
+	}
+
+	if result := Piecewise()(1); result != 0 {
+		t.Errorf("algorithm expected to return a 0 duration, but received %s instead", result)
+	}
+}
@@ -0,0 +1,40 @@
+package backoff
+
+import (
+	"time"
+
+	"github.com/Rican7/retry/internal/describe"
+)
+
+// constructors holds what describes each Algorithm created by this package.
+var constructors describe.Registry[Algorithm, describe.Constructor]
+
+// newAlgorithm creates an Algorithm that calculates durations with the given
+// algorithm, and that's described by the given constructor name and arguments.
+func newAlgorithm(algorithm Algorithm, name string, args ...any) Algorithm {
+	return constructors.Register(func(attempt uint) time.Duration {
+		return algorithm(attempt)
+	}, describe.Constructor{Name: name, Args: args})
+}
+
+// String describes the Algorithm by the call of the constructor that created
+// it, such as "Exponential(10ms, 2)". An Algorithm that wasn't created by this
+// package is described as "<custom>".
+func (a Algorithm) String() string {
+	if a == nil {
+		return "<nil>"
+	}
+
+	constructor, ok := constructors.Lookup(a)
+
+	if !ok {
+		return "<custom>"
+	}
+
+	return constructor.String()
+}
+
+// String describes the Piece, such as "Piece(3, Linear(10ms))".
+func (p Piece) String() string {
+	return describe.Call("Piece", p.UpTo, p.Algorithm)
+}
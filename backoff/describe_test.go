@@ -0,0 +1,48 @@
+package backoff
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAlgorithmString(t *testing.T) {
+	const duration = 10 * time.Millisecond
+
+	algorithms := map[string]Algorithm{
+		"Incremental(10ms, 5ms)":   Incremental(duration, 5*time.Millisecond),
+		"Linear(10ms)":             Linear(duration),
+		"Exponential(10ms, 3)":     Exponential(duration, 3),
+		"BinaryExponential(10ms)":  BinaryExponential(duration),
+		"Polynomial(10ms, 2)":      Polynomial(duration, 2),
+		"Logarithmic(10ms)":        Logarithmic(duration),
+		"Fibonacci(10ms)":          Fibonacci(duration),
+		"Scale(Linear(10ms), 1.5)": Scale(Linear(duration), 1.5),
+		"Shift(Linear(10ms), -2)":  Shift(Linear(duration), -2),
+		"Add(Linear(10ms), <custom>)": Add(Linear(duration), func(attempt uint) time.Duration {
+			return duration
+		}),
+		"Min(Max(Linear(10ms)), Incremental(1s, 0s))": Min(Max(Linear(duration)), Incremental(time.Second, 0)),
+		"Piecewise(Piece(3, Linear(10ms)), Piece(0, Fibonacci(10ms)))": Piecewise(
+			Piece{UpTo: 3, Algorithm: Linear(duration)},
+			Piece{Algorithm: Fibonacci(duration)},
+		),
+		"<nil>": nil,
+	}
+
+	for expected, algorithm := range algorithms {
+		if description := algorithm.String(); description != expected {
+			t.Errorf("expected the description %q, received %q instead", expected, description)
+		}
+	}
+}
+
+func TestAlgorithmStringDoesNotAffectCalculation(t *testing.T) {
+	algorithm := Polynomial(time.Millisecond, 2)
+
+	_ = algorithm.String()
+
+	if result := algorithm(math.MaxUint); result != maxDuration {
+		t.Errorf("algorithm expected to return a %s duration, but received %s instead", maxDuration, result)
+	}
+}
@@ -0,0 +1,118 @@
+package retry
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Rican7/retry/strategy"
+)
+
+// ErrBatchMismatch is wrapped by the error returned by Batch when the action
+// doesn't return exactly one error for each item that it's passed.
+var ErrBatchMismatch = errors.New("retry: batch action returned a mismatched number of errors")
+
+// BatchAction defines a callable function that Batch can handle.
+//
+// The action is passed the items of the batch that are still pending, and must
+// return exactly one error for each of them, in the same order. A nil error
+// marks the corresponding item as successful.
+type BatchAction[T any] func(attempt uint, items []T) []error
+
+// BatchError reports the items of a batch that never succeeded, along with the
+// last error returned for each of them.
+type BatchError[T any] struct {
+	// Items are the items that never succeeded.
+	Items []T
+
+	// Errors are the last errors returned for each of the Items, in the same
+	// order. An error is nil if its item was never attempted.
+	Errors []error
+}
+
+// Error returns a description of the failed items.
+func (e *BatchError[T]) Error() string {
+	for _, err := range e.Errors {
+		if err != nil {
+			return fmt.Sprintf("retry: %d batch items failed, first error: %v", len(e.Items), err)
+		}
+	}
+
+	return fmt.Sprintf("retry: %d batch items failed", len(e.Items))
+}
+
+// Batch takes a batch of items and an action, and performs the action with the
+// items, repetitively, until successful for every item. Each attempt after the
+// first is passed only the items that failed in the previous attempt. An item
+// whose error is marked as Permanent is never retried.
+//
+// Optionally, strategies may be passed that assess whether or not an attempt
+// should be made.
+//
+// If any items never succeed, a *BatchError is returned that reports them. If
+// the action doesn't return exactly one error for each item, an error that
+// wraps ErrBatchMismatch is returned instead, without any further attempts.
+func Batch[T any](items []T, action BatchAction[T], strategies ...strategy.Strategy) error {
+	errs := make([]error, len(items))
+
+	// pending holds the indexes of the items that are still to be attempted
+	pending := make([]int, len(items))
+
+	for i := range pending {
+		pending[i] = i
+	}
+
+	for attempt := uint(0); len(pending) > 0 && shouldAttempt(attempt, strategies...); attempt++ {
+		pendingItems := make([]T, len(pending))
+
+		for j, i := range pending {
+			pendingItems[j] = items[i]
+		}
+
+		results := action(attempt+1, pendingItems)
+
+		if len(results) != len(pending) {
+			return fmt.Errorf("%w: %d errors for %d items", ErrBatchMismatch, len(results), len(pending))
+		}
+
+		var retryable []int
+
+		for j, err := range results {
+			i := pending[j]
+			errs[i] = err
+
+			if err != nil && !IsPermanent(err) {
+				retryable = append(retryable, i)
+			}
+		}
+
+		pending = retryable
+	}
+
+	return newBatchError(items, errs, pending)
+}
+
+// newBatchError returns a *BatchError that reports the given items that have a
+// non-nil error, or that are still pending, in their original order, or nil if
+// there aren't any.
+func newBatchError[T any](items []T, errs []error, pending []int) error {
+	stillPending := make(map[int]bool, len(pending))
+
+	for _, i := range pending {
+		stillPending[i] = true
+	}
+
+	var batchErr BatchError[T]
+
+	for i, err := range errs {
+		if err != nil || stillPending[i] {
+			batchErr.Items = append(batchErr.Items, items[i])
+			batchErr.Errors = append(batchErr.Errors, err)
+		}
+	}
+
+	if len(batchErr.Items) == 0 {
+		return nil
+	}
+
+	return &batchErr
+}
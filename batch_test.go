@@ -0,0 +1,191 @@
+package retry
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Rican7/retry/strategy"
+)
+
+func TestBatch(t *testing.T) {
+	items := []string{"a", "b", "c", "d"}
+
+	// Each item fails until the given attempt
+	succeedOnAttempt := map[string]uint{"a": 1, "b": 3, "c": 1, "d": 2}
+
+	var pendingItems [][]string
+
+	action := func(attempt uint, items []string) []error {
+		pendingItems = append(pendingItems, items)
+
+		errs := make([]error, len(items))
+
+		for i, item := range items {
+			if attempt < succeedOnAttempt[item] {
+				errs[i] = errors.New("erroring")
+			}
+		}
+
+		return errs
+	}
+
+	err := Batch(items, action)
+
+	if err != nil {
+		t.Errorf("expected a nil error, received %q instead", err)
+	}
+
+	expected := [][]string{{"a", "b", "c", "d"}, {"b", "d"}, {"b"}}
+
+	if !reflect.DeepEqual(expected, pendingItems) {
+		t.Errorf("expected the pending items %v, received %v instead", expected, pendingItems)
+	}
+}
+
+func TestBatchReportsFailedItems(t *testing.T) {
+	const attemptLimit = 3
+
+	items := []int{1, 2, 3, 4, 5}
+
+	var attemptsMade uint
+
+	action := func(attempt uint, items []int) []error {
+		attemptsMade = attempt
+
+		errs := make([]error, len(items))
+
+		for i, item := range items {
+			if item%2 == 0 {
+				errs[i] = errors.New("even")
+			}
+		}
+
+		return errs
+	}
+
+	err := Batch(items, action, strategy.Limit(attemptLimit))
+
+	var batchErr *BatchError[int]
+
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected a batch error, received %q instead", err)
+	}
+
+	if expected := []int{2, 4}; !reflect.DeepEqual(expected, batchErr.Items) {
+		t.Errorf("expected the failed items %v, received %v instead", expected, batchErr.Items)
+	}
+
+	if len(batchErr.Errors) != len(batchErr.Items) {
+		t.Errorf("expected an error for each failed item, received %d instead", len(batchErr.Errors))
+	}
+
+	if attemptLimit != attemptsMade {
+		t.Errorf("expected %d attempts to be made, but %d were made instead", attemptLimit, attemptsMade)
+	}
+
+	if expected := "retry: 2 batch items failed, first error: even"; err.Error() != expected {
+		t.Errorf("expected the error message %q, received %q instead", expected, err)
+	}
+}
+
+func TestBatchNoAttempts(t *testing.T) {
+	items := []int{1, 2}
+
+	action := func(attempt uint, items []int) []error {
+		t.Error("expected the action to not be called")
+
+		return nil
+	}
+
+	err := Batch(items, action, strategy.Limit(0))
+
+	var batchErr *BatchError[int]
+
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected a batch error, received %q instead", err)
+	}
+
+	if !reflect.DeepEqual(items, batchErr.Items) {
+		t.Errorf("expected the failed items %v, received %v instead", items, batchErr.Items)
+	}
+
+	if expected := "retry: 2 batch items failed"; err.Error() != expected {
+		t.Errorf("expected the error message %q, received %q instead", expected, err)
+	}
+}
+
+func TestBatchEmpty(t *testing.T) {
+	action := func(attempt uint, items []int) []error {
+		t.Error("expected the action to not be called")
+
+		return nil
+	}
+
+	if err := Batch(nil, action); err != nil {
+		t.Errorf("expected a nil error, received %q instead", err)
+	}
+}
+
+func TestBatchMismatchedErrors(t *testing.T) {
+	var attemptsMade uint
+
+	err := Batch([]int{1, 2}, func(attempt uint, items []int) []error {
+		attemptsMade = attempt
+
+		return nil
+	})
+
+	if !errors.Is(err, ErrBatchMismatch) {
+		t.Errorf("expected a mismatch error, received %q instead", err)
+	}
+
+	if attemptsMade != 1 {
+		t.Errorf("expected 1 attempt to be made, but %d were made instead", attemptsMade)
+	}
+}
+
+func TestBatchPermanentErrors(t *testing.T) {
+	items := []int{1, 2, 3, 4}
+
+	errFatal := errors.New("fatal")
+
+	var attempted []int
+
+	action := func(attempt uint, items []int) []error {
+		attempted = append(attempted, items...)
+
+		errs := make([]error, len(items))
+
+		for i, item := range items {
+			switch {
+			case item == 2:
+				errs[i] = Permanent(errFatal)
+			case item == 4 && attempt < 3:
+				errs[i] = errors.New("transient")
+			}
+		}
+
+		return errs
+	}
+
+	err := Batch(items, action)
+
+	var batchErr *BatchError[int]
+
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected a batch error, received %q instead", err)
+	}
+
+	if expected := []int{2}; !reflect.DeepEqual(expected, batchErr.Items) {
+		t.Errorf("expected the failed items %v, received %v instead", expected, batchErr.Items)
+	}
+
+	if len(batchErr.Errors) != 1 || !errors.Is(batchErr.Errors[0], errFatal) {
+		t.Errorf("expected the permanent error, received %v instead", batchErr.Errors)
+	}
+
+	if expected := []int{1, 2, 3, 4, 4, 4}; !reflect.DeepEqual(expected, attempted) {
+		t.Errorf("expected the attempted items %v, received %v instead", expected, attempted)
+	}
+}
@@ -0,0 +1,37 @@
+// Package clock provides a way to tell and wait on time, so that time-based
+// behavior may be controlled (such as in tests).
+//
+// Copyright © 2026 Trevor N. Suarez (Rican7)
+package clock
+
+import "time"
+
+// Clock defines a source of the current time and of timers.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// After waits for the given duration to elapse and then sends the current
+	// time on the returned channel.
+	After(duration time.Duration) <-chan time.Time
+}
+
+// System returns a Clock that uses the system's time, as provided by package
+// time.
+func System() Clock {
+	return system{}
+}
+
+// system is a Clock that uses the system's time.
+type system struct{}
+
+// Now returns the current system time.
+func (system) Now() time.Time {
+	return time.Now()
+}
+
+// After returns a channel that receives the current system time after the given
+// duration has elapsed.
+func (system) After(duration time.Duration) <-chan time.Time {
+	return time.After(duration)
+}
@@ -0,0 +1,36 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+// timeMarginOfError represents the acceptable amount of time that may pass for
+// a time-based (sleep) unit before considering invalid.
+const timeMarginOfError = time.Millisecond
+
+func TestSystemNow(t *testing.T) {
+	clock := System()
+
+	before := time.Now()
+	now := clock.Now()
+	after := time.Now()
+
+	if now.Before(before) || now.After(after) {
+		t.Errorf("clock expected to return a time between %s and %s, received %s instead", before, after, now)
+	}
+}
+
+func TestSystemAfter(t *testing.T) {
+	const duration = 10 * timeMarginOfError
+
+	clock := System()
+
+	now := time.Now()
+
+	<-clock.After(duration)
+
+	if duration > time.Since(now) {
+		t.Errorf("clock expected to wait %s", duration)
+	}
+}
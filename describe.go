@@ -0,0 +1,44 @@
+package retry
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Rican7/retry/strategy"
+)
+
+// Describe returns a description of the given strategies, suitable for logs or
+// debugging, such as "Limit(5) + Backoff(Exponential(10ms, 2))".
+func Describe(strategies ...strategy.Strategy) string {
+	factories := make([]strategy.Factory, len(strategies))
+
+	for i, strategy := range strategies {
+		factories[i] = strategy
+	}
+
+	return describeFactories(factories)
+}
+
+// String describes the strategies of the Policy, as with Describe.
+func (p *Policy) String() string {
+	return describeFactories(p.strategies)
+}
+
+// describeFactories describes each of the given factories, joined together.
+func describeFactories(factories []strategy.Factory) string {
+	if len(factories) == 0 {
+		return "<none>"
+	}
+
+	descriptions := make([]string, len(factories))
+
+	for i, factory := range factories {
+		if stringer, ok := factory.(fmt.Stringer); ok {
+			descriptions[i] = stringer.String()
+		} else {
+			descriptions[i] = fmt.Sprintf("%T", factory)
+		}
+	}
+
+	return strings.Join(descriptions, " + ")
+}
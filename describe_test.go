@@ -0,0 +1,47 @@
+package retry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Rican7/retry/backoff"
+	"github.com/Rican7/retry/jitter"
+	"github.com/Rican7/retry/strategy"
+)
+
+// budgetFactory is a strategy.Factory that doesn't describe itself.
+type budgetFactory struct{}
+
+func (budgetFactory) New() strategy.Strategy {
+	return strategy.Limit(1)
+}
+
+func TestDescribe(t *testing.T) {
+	description := Describe(
+		strategy.Limit(5),
+		strategy.BackoffWithJitter(backoff.Exponential(10*time.Millisecond, 2), jitter.Equal(nil)),
+	)
+
+	if expected := "Limit(5) + BackoffWithJitter(Exponential(10ms, 2), Equal)"; description != expected {
+		t.Errorf("expected the description %q, received %q instead", expected, description)
+	}
+}
+
+func TestDescribeNone(t *testing.T) {
+	if description := Describe(); description != "<none>" {
+		t.Errorf("expected the description %q, received %q instead", "<none>", description)
+	}
+}
+
+func TestPolicyString(t *testing.T) {
+	policy := NewPolicy(
+		WithStrategies(strategy.Delay(time.Millisecond)),
+		WithFactories(budgetFactory{}),
+	)
+
+	expected := "Delay(1ms) + retry.budgetFactory"
+
+	if description := policy.String(); description != expected {
+		t.Errorf("expected the description %q, received %q instead", expected, description)
+	}
+}
@@ -0,0 +1,58 @@
+package retry
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrAttemptsExhausted is matched (by errors.Is) by the error returned when a
+// strategy halts the retrying process before the action succeeds, by a Policy
+// created WithExhaustedError.
+var ErrAttemptsExhausted = errors.New("retry: attempts exhausted")
+
+// ExhaustedError is returned by a Policy created WithExhaustedError when a
+// strategy halts the retrying process before the action succeeds. It wraps the
+// last error returned by the action.
+type ExhaustedError struct {
+	// Err is the last error returned by the action.
+	Err error
+
+	// Attempts is the number of attempts that were made.
+	Attempts uint
+
+	// Strategy is the index of the strategy that halted the retrying process,
+	// in the order that the strategies were given.
+	Strategy int
+
+	// Description describes the strategy that halted the retrying process,
+	// such as "Limit(3)".
+	Description string
+}
+
+// Error returns a description of the exhaustion and the last error.
+func (e *ExhaustedError) Error() string {
+	attempts := "attempts"
+
+	if e.Attempts == 1 {
+		attempts = "attempt"
+	}
+
+	return fmt.Sprintf(
+		"%s after %d %s (halted by %s): %v",
+		ErrAttemptsExhausted,
+		e.Attempts,
+		attempts,
+		e.Description,
+		e.Err,
+	)
+}
+
+// Unwrap returns the last error returned by the action.
+func (e *ExhaustedError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the given target is ErrAttemptsExhausted.
+func (e *ExhaustedError) Is(target error) bool {
+	return target == ErrAttemptsExhausted
+}
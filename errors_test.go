@@ -0,0 +1,132 @@
+package retry
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/Rican7/retry/strategy"
+)
+
+func TestRetryReturnsLastError(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	err := Retry(func(attempt uint) error {
+		return errFailed
+	}, strategy.Limit(3))
+
+	if err != errFailed {
+		t.Errorf("expected the action's error, received %q instead", err)
+	}
+}
+
+func TestPolicyExhaustedError(t *testing.T) {
+	const attemptLimit = 3
+
+	policy := NewPolicy(
+		WithStrategies(strategy.Wait(), strategy.Limit(attemptLimit)),
+		WithExhaustedError(),
+	)
+
+	err := policy.Do(func(attempt uint) error {
+		return &fs.PathError{Op: "open", Path: "/flaky", Err: fs.ErrNotExist}
+	})
+
+	if !errors.Is(err, ErrAttemptsExhausted) {
+		t.Errorf("expected an exhausted error, received %q instead", err)
+	}
+
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Error("expected the error to wrap the action's error")
+	}
+
+	var pathErr *fs.PathError
+
+	if !errors.As(err, &pathErr) || pathErr.Path != "/flaky" {
+		t.Error("expected the error to unwrap to the action's error type")
+	}
+
+	var exhaustedErr *ExhaustedError
+
+	if !errors.As(err, &exhaustedErr) {
+		t.Fatalf("expected an *ExhaustedError, received %T instead", err)
+	}
+
+	if exhaustedErr.Attempts != attemptLimit {
+		t.Errorf("expected %d attempts, received %d instead", attemptLimit, exhaustedErr.Attempts)
+	}
+
+	if exhaustedErr.Strategy != 1 {
+		t.Errorf("expected the strategy at index 1 to halt, received %d instead", exhaustedErr.Strategy)
+	}
+
+	if exhaustedErr.Description != "Limit(3)" {
+		t.Errorf("expected the description %q, received %q instead", "Limit(3)", exhaustedErr.Description)
+	}
+
+	expected := "retry: attempts exhausted after 3 attempts (halted by Limit(3)): open /flaky: file does not exist"
+
+	if err.Error() != expected {
+		t.Errorf("expected the error message %q, received %q instead", expected, err)
+	}
+}
+
+func TestExhaustedErrorSingleAttempt(t *testing.T) {
+	err := NewPolicy(WithStrategies(strategy.Limit(1)), WithExhaustedError()).Do(func(attempt uint) error {
+		return errors.New("failed")
+	})
+
+	expected := "retry: attempts exhausted after 1 attempt (halted by Limit(1)): failed"
+
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected the error message %q, received %q instead", expected, err)
+	}
+}
+
+func TestPolicyPermanentNotExhausted(t *testing.T) {
+	errCause := errors.New("cause")
+
+	err := NewPolicy(WithStrategies(strategy.Limit(3)), WithExhaustedError()).Do(func(attempt uint) error {
+		return Permanent(errCause)
+	})
+
+	if errors.Is(err, ErrAttemptsExhausted) {
+		t.Error("expected a permanent error to not be an exhausted error")
+	}
+
+	if !errors.Is(err, errCause) {
+		t.Errorf("expected the action's error, received %q instead", err)
+	}
+}
+
+func TestPolicyNoAttemptsNotExhausted(t *testing.T) {
+	err := NewPolicy(WithStrategies(strategy.Limit(0)), WithExhaustedError()).Do(func(attempt uint) error {
+		return errors.New("erroring")
+	})
+
+	if err != nil {
+		t.Errorf("expected a nil error, received %q instead", err)
+	}
+}
+
+func TestHaltingStrategy(t *testing.T) {
+	trueStrategy := func(attempt uint) bool {
+		return true
+	}
+
+	falseStrategy := func(attempt uint) bool {
+		return false
+	}
+
+	if halting := haltingStrategy(1); halting != -1 {
+		t.Errorf("expected -1, received %d instead", halting)
+	}
+
+	if halting := haltingStrategy(1, trueStrategy, trueStrategy); halting != -1 {
+		t.Errorf("expected -1, received %d instead", halting)
+	}
+
+	if halting := haltingStrategy(1, trueStrategy, falseStrategy, falseStrategy); halting != 1 {
+		t.Errorf("expected 1, received %d instead", halting)
+	}
+}
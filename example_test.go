@@ -93,3 +93,23 @@ func Example_withBackoffJitter() {
 	// attempt 4
 	// attempt 5
 }
+
+func Example_policy() {
+	policy := retry.NewPolicy(
+		retry.WithStrategies(
+			strategy.Limit(5),
+			strategy.Backoff(backoff.Fibonacci(10*time.Millisecond)),
+		),
+		retry.WithClassifiers(func(err error) bool {
+			return !errors.Is(err, os.ErrPermission)
+		}),
+	)
+
+	err := policy.Do(func(attempt uint) error {
+		return nil // Do something that may or may not cause an error
+	})
+
+	if err != nil {
+		log.Fatalf("Failed with error %q", err)
+	}
+}
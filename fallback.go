@@ -0,0 +1,84 @@
+package retry
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Rican7/retry/strategy"
+)
+
+// FallbackError is returned when both an action and its fallback fail. It wraps
+// both errors, so that errors.Is and errors.As match either of them.
+type FallbackError struct {
+	// Err is the final error returned by the action.
+	Err error
+
+	// FallbackErr is the error returned by the fallback.
+	FallbackErr error
+}
+
+// Error returns a description of both errors.
+func (e *FallbackError) Error() string {
+	return fmt.Sprintf("retry: fallback failed with %q after action failed with %q", e.FallbackErr, e.Err)
+}
+
+// Unwrap returns the fallback's error.
+func (e *FallbackError) Unwrap() error {
+	return e.FallbackErr
+}
+
+// Is reports whether the action's error matches the given target.
+func (e *FallbackError) Is(target error) bool {
+	return errors.Is(e.Err, target)
+}
+
+// As finds the first error in the action's error chain that matches the given
+// target.
+func (e *FallbackError) As(target any) bool {
+	return errors.As(e.Err, target)
+}
+
+// DoOrElse takes an action and performs it, repetitively, until successful,
+// returning its value. If the retrying process halts before the action is
+// successful, the given fallback is called with the final error returned by the
+// action (or ErrAttemptsExhausted, if no attempt was made), and the fallback's
+// result is returned instead.
+//
+// Optionally, strategies may be passed that assess whether or not an attempt
+// should be made.
+//
+// If the fallback also fails, a *FallbackError is returned that wraps both the
+// action's and the fallback's errors.
+func DoOrElse[T any](
+	action func(attempt uint) (T, error),
+	fallback func(err error) (T, error),
+	strategies ...strategy.Strategy,
+) (T, error) {
+	var value T
+	var succeeded bool
+
+	err := Retry(func(attempt uint) error {
+		var err error
+
+		value, err = action(attempt)
+		succeeded = err == nil
+
+		return err
+	}, strategies...)
+
+	if succeeded {
+		return value, nil
+	}
+
+	if err == nil {
+		err = ErrAttemptsExhausted
+	}
+
+	value, fallbackErr := fallback(err)
+
+	if fallbackErr != nil {
+		return value, &FallbackError{Err: err, FallbackErr: fallbackErr}
+	}
+
+	return value, nil
+}
@@ -0,0 +1,143 @@
+package retry
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/Rican7/retry/strategy"
+)
+
+func TestDoOrElse(t *testing.T) {
+	action := func(attempt uint) (string, error) {
+		return "fresh", nil
+	}
+
+	fallback := func(err error) (string, error) {
+		t.Error("expected the fallback to not be called")
+
+		return "stale", nil
+	}
+
+	value, err := DoOrElse(action, fallback)
+
+	if err != nil {
+		t.Errorf("expected a nil error, received %q instead", err)
+	}
+
+	if value != "fresh" {
+		t.Errorf("expected the action's value, received %q instead", value)
+	}
+}
+
+func TestDoOrElseFallsBack(t *testing.T) {
+	const attemptLimit = 3
+
+	errFailed := errors.New("failed")
+
+	var attemptsMade uint
+	var fallbackErr error
+
+	action := func(attempt uint) (string, error) {
+		attemptsMade = attempt
+
+		return "partial", errFailed
+	}
+
+	fallback := func(err error) (string, error) {
+		fallbackErr = err
+
+		return "stale", nil
+	}
+
+	value, err := DoOrElse(action, fallback, strategy.Limit(attemptLimit))
+
+	if err != nil {
+		t.Errorf("expected a nil error, received %q instead", err)
+	}
+
+	if value != "stale" {
+		t.Errorf("expected the fallback's value, received %q instead", value)
+	}
+
+	if fallbackErr != errFailed {
+		t.Errorf("expected the fallback to receive the action's error, received %q instead", fallbackErr)
+	}
+
+	if attemptLimit != attemptsMade {
+		t.Errorf("expected %d attempts to be made, but %d were made instead", attemptLimit, attemptsMade)
+	}
+}
+
+func TestDoOrElseFallbackFails(t *testing.T) {
+	action := func(attempt uint) (int, error) {
+		return 0, &fs.PathError{Op: "open", Path: "/primary", Err: fs.ErrNotExist}
+	}
+
+	fallback := func(err error) (int, error) {
+		return -1, fs.ErrPermission
+	}
+
+	value, err := DoOrElse(action, fallback, strategy.Limit(1))
+
+	if value != -1 {
+		t.Errorf("expected the fallback's value, received %d instead", value)
+	}
+
+	var fallbackErr *FallbackError
+
+	if !errors.As(err, &fallbackErr) {
+		t.Fatalf("expected a fallback error, received %q instead", err)
+	}
+
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Error("expected the error to match the action's error")
+	}
+
+	if !errors.Is(err, fs.ErrPermission) {
+		t.Error("expected the error to match the fallback's error")
+	}
+
+	var pathErr *os.PathError
+
+	if !errors.As(err, &pathErr) || pathErr.Path != "/primary" {
+		t.Error("expected the error to unwrap to the action's error type")
+	}
+
+	expected := `retry: fallback failed with "permission denied" after action failed with "open /primary: file does not exist"`
+
+	if err.Error() != expected {
+		t.Errorf("expected the error message %q, received %q instead", expected, err)
+	}
+}
+
+func TestDoOrElseNoAttempts(t *testing.T) {
+	action := func(attempt uint) (string, error) {
+		t.Error("expected the action to not be called")
+
+		return "fresh", nil
+	}
+
+	var fallbackErr error
+
+	fallback := func(err error) (string, error) {
+		fallbackErr = err
+
+		return "stale", nil
+	}
+
+	value, err := DoOrElse(action, fallback, strategy.Limit(0))
+
+	if err != nil {
+		t.Errorf("expected a nil error, received %q instead", err)
+	}
+
+	if value != "stale" {
+		t.Errorf("expected the fallback's value, received %q instead", value)
+	}
+
+	if fallbackErr != ErrAttemptsExhausted {
+		t.Errorf("expected the fallback to receive ErrAttemptsExhausted, received %q instead", fallbackErr)
+	}
+}
@@ -0,0 +1,100 @@
+package retry
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Rican7/retry/strategy"
+)
+
+// ItemAction defines a callable function that ForEach can handle, which
+// performs an action for a single item and returns its result.
+type ItemAction[T, R any] func(ctx context.Context, item T, attempt uint) (R, error)
+
+// ForEach takes a list of items and an action, and performs the action for each
+// item, repetitively, until successful. The items are processed in parallel by
+// a pool of at most the given concurrency of goroutines (and at least one).
+//
+// Optionally, strategy factories may be passed, each of which is used to create
+// a new Strategy for every item, that assesses whether or not an attempt should
+// be made. A Strategy may be passed as a factory as is, in which case it's
+// shared by every item, and must therefore be safe for concurrent use (as the
+// strategies of package strategy are, unless given a random generator that
+// isn't). Sharing a strategy that limits a shared resource, such as
+// `strategy.RateLimit`, applies the limit across all of the items.
+//
+// The results and errors of each item are returned in the same order as the
+// items. If an item's action returns an error marked as Permanent, the context
+// passed to the other actions is cancelled, and any items not yet started
+// are skipped with the context's error.
+func ForEach[T, R any](
+	ctx context.Context,
+	items []T,
+	concurrency int,
+	action ItemAction[T, R],
+	factories ...strategy.Factory,
+) ([]R, []error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]R, len(items))
+	errs := make([]error, len(items))
+
+	policy := NewPolicy(WithFactories(factories...))
+	indexes := make(chan int)
+
+	workers := concurrency
+
+	if workers < 1 {
+		workers = 1
+	}
+
+	if workers > len(items) {
+		workers = len(items)
+	}
+
+	var wg sync.WaitGroup
+
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				errs[i] = policy.DoContext(ctx, func(attempt uint) error {
+					var err error
+
+					results[i], err = action(ctx, items[i], attempt)
+
+					return err
+				})
+
+				if IsPermanent(errs[i]) {
+					cancel()
+				}
+			}
+		}()
+	}
+
+	started := 0
+
+feed:
+	for started < len(items) {
+		select {
+		case indexes <- started:
+			started++
+		case <-ctx.Done():
+			break feed
+		}
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	for i := started; i < len(items); i++ {
+		errs[i] = ctx.Err()
+	}
+
+	return results, errs
+}
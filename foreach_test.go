@@ -0,0 +1,258 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Rican7/retry/backoff"
+	"github.com/Rican7/retry/jitter"
+	"github.com/Rican7/retry/strategy"
+)
+
+func TestForEach(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8}
+
+	goroutines := runtime.NumGoroutine()
+
+	action := func(ctx context.Context, item int, attempt uint) (string, error) {
+		if attempt < uint(item%3) {
+			return "", errors.New("erroring")
+		}
+
+		return fmt.Sprintf("%d@%d", item, attempt), nil
+	}
+
+	results, errs := ForEach(context.Background(), items, 3, action)
+
+	for i, item := range items {
+		if errs[i] != nil {
+			t.Errorf("expected a nil error for item %d, received %q instead", item, errs[i])
+		}
+
+		attempt := item % 3
+
+		if attempt == 0 {
+			attempt = 1
+		}
+
+		if expected := fmt.Sprintf("%d@%d", item, attempt); results[i] != expected {
+			t.Errorf("expected the result %q for item %d, received %q instead", expected, item, results[i])
+		}
+	}
+
+	assertNoGoroutineLeak(t, goroutines)
+}
+
+func TestForEachBoundsConcurrency(t *testing.T) {
+	const concurrency = 3
+
+	items := make([]int, 20)
+
+	var running, maxRunning int32
+
+	action := func(ctx context.Context, item int, attempt uint) (int, error) {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+
+			if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond)
+
+		return item, nil
+	}
+
+	ForEach(context.Background(), items, concurrency, action)
+
+	if maxRunning := atomic.LoadInt32(&maxRunning); maxRunning > concurrency {
+		t.Errorf("expected at most %d actions to run concurrently, but %d did", concurrency, maxRunning)
+	}
+}
+
+func TestForEachWithStrategies(t *testing.T) {
+	const attemptLimit = 2
+
+	items := []string{"a", "b"}
+
+	var attemptsMade uint32
+
+	action := func(ctx context.Context, item string, attempt uint) (int, error) {
+		atomic.AddUint32(&attemptsMade, 1)
+
+		return 0, errors.New("erroring")
+	}
+
+	_, errs := ForEach(context.Background(), items, 0, action, strategy.Limit(attemptLimit))
+
+	for i, err := range errs {
+		if err == nil {
+			t.Errorf("expected a non-nil error for item %q", items[i])
+		}
+	}
+
+	if expected := uint32(len(items) * attemptLimit); atomic.LoadUint32(&attemptsMade) != expected {
+		t.Errorf("expected %d attempts to be made, but %d were made instead", expected, attemptsMade)
+	}
+}
+
+func TestForEachWithFactories(t *testing.T) {
+	const budget = 3
+
+	items := []string{"a", "b", "c", "d"}
+
+	var attemptsMade uint32
+
+	// A budget that holds state between attempts, which can't be shared
+	factory := strategy.FactoryFunc(func() strategy.Strategy {
+		remaining := budget
+
+		return func(attempt uint) bool {
+			remaining--
+
+			return remaining >= 0
+		}
+	})
+
+	action := func(ctx context.Context, item string, attempt uint) (int, error) {
+		atomic.AddUint32(&attemptsMade, 1)
+
+		return 0, errors.New("erroring")
+	}
+
+	ForEach(context.Background(), items, len(items), action, factory)
+
+	if expected := uint32(len(items) * budget); atomic.LoadUint32(&attemptsMade) != expected {
+		t.Errorf("expected %d attempts to be made, but %d were made instead", expected, attemptsMade)
+	}
+}
+
+func TestForEachSharedStrategiesConcurrentUse(t *testing.T) {
+	const attemptLimit = 3
+
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8}
+
+	action := func(ctx context.Context, item int, attempt uint) (int, error) {
+		return 0, errors.New("erroring")
+	}
+
+	_, errs := ForEach(
+		context.Background(),
+		items,
+		len(items),
+		action,
+		strategy.Limit(attemptLimit),
+		strategy.BackoffWithJitter(backoff.Linear(time.Microsecond), jitter.Full(nil)),
+	)
+
+	for i, err := range errs {
+		if err == nil {
+			t.Errorf("expected a non-nil error for item %d", items[i])
+		}
+	}
+}
+
+func TestForEachFailFast(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	errFatal := errors.New("fatal")
+
+	action := func(ctx context.Context, item int, attempt uint) (int, error) {
+		if item == 2 {
+			return 0, Permanent(errFatal)
+		}
+
+		if item == 1 {
+			// Wait to be cancelled by the permanent failure
+			<-ctx.Done()
+
+			return 0, ctx.Err()
+		}
+
+		return item, nil
+	}
+
+	_, errs := ForEach(context.Background(), items, 2, action)
+
+	if !errors.Is(errs[1], errFatal) {
+		t.Errorf("expected the permanent error, received %q instead", errs[1])
+	}
+
+	if errs[0] != context.Canceled {
+		t.Errorf("expected the in-flight item to be cancelled, received %q instead", errs[0])
+	}
+
+	for i := 2; i < len(items); i++ {
+		if errs[i] != nil && errs[i] != context.Canceled {
+			t.Errorf("expected a nil or context canceled error, received %q instead", errs[i])
+		}
+	}
+
+	if errs[len(items)-1] != context.Canceled {
+		t.Errorf("expected the last item to be skipped, received %q instead", errs[len(items)-1])
+	}
+}
+
+func TestForEachFailFastWithRateLimit(t *testing.T) {
+	items := []int{1, 2, 3, 4}
+
+	errFatal := errors.New("fatal")
+
+	// Only the first attempt is allowed before the limiter blocks for an hour
+	limiter := strategy.NewTokenBucket(1, time.Hour, nil)
+
+	action := func(ctx context.Context, item int, attempt uint) (int, error) {
+		return 0, Permanent(errFatal)
+	}
+
+	done := make(chan []error)
+
+	go func() {
+		_, errs := ForEach(context.Background(), items, 2, action, strategy.RateLimit(limiter))
+
+		done <- errs
+	}()
+
+	select {
+	case errs := <-done:
+		var fatal, canceled int
+
+		for _, err := range errs {
+			switch {
+			case errors.Is(err, errFatal):
+				fatal++
+			case err == context.Canceled:
+				canceled++
+			}
+		}
+
+		if fatal != 1 || canceled != len(items)-1 {
+			t.Errorf("expected 1 permanent error and the rest cancelled, received %v instead", errs)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the items waiting on the limiter to be cancelled")
+	}
+}
+
+func TestForEachEmpty(t *testing.T) {
+	action := func(ctx context.Context, item int, attempt uint) (int, error) {
+		t.Error("expected the action to not be called")
+
+		return 0, nil
+	}
+
+	results, errs := ForEach(context.Background(), nil, 5, action)
+
+	if len(results) != 0 || len(errs) != 0 {
+		t.Error("expected no results or errors")
+	}
+}
@@ -0,0 +1,209 @@
+package retry
+
+import (
+	"context"
+	"time"
+
+	"github.com/Rican7/retry/backoff"
+	"github.com/Rican7/retry/clock"
+	"github.com/Rican7/retry/strategy"
+)
+
+// hedgeResult is the result of a single attempt made by Hedge.
+type hedgeResult struct {
+	// attempt describes the attempt, if the action was called.
+	attempt Attempt
+
+	// halting is the index of the strategy that halted the hedging process
+	// instead of the action being called, or -1 if none did.
+	halting int
+
+	// skipped is whether the action wasn't called because an earlier attempt
+	// already halted the hedging process.
+	skipped bool
+}
+
+// Hedge takes an action and performs it, speculatively, in parallel until
+// successful.
+//
+// Optionally, strategies may be passed that assess whether or not an attempt
+// should be made, just as with Retry. As such, `strategy.Limit(n)` limits the
+// number of attempts made to n.
+//
+// Hedge is equivalent to calling Hedge on a Policy created with the given
+// strategies.
+func Hedge(
+	ctx context.Context,
+	action ContextAction,
+	delay backoff.Algorithm,
+	maxInFlight uint,
+	strategies ...strategy.Strategy,
+) error {
+	return NewPolicy(WithStrategies(strategies...)).Hedge(ctx, action, delay, maxInFlight)
+}
+
+// Hedge takes an action and performs it, speculatively, in parallel until
+// successful or until the Policy halts the hedging process.
+//
+// The first attempt is made immediately. After a delay, as defined by the given
+// backoff.Algorithm and waited on the Policy's clock, another attempt is
+// started, in parallel with any attempts still in flight, and so on, up to the
+// given maximum number of attempts in flight (and at least one). An attempt that
+// fails frees its slot, but the next attempt still waits for the delay, so that
+// a failing action isn't called in a busy loop. The first attempt to succeed
+// wins, and the context passed to the other attempts is cancelled. For a fixed
+// delay, use a constant algorithm, such as `backoff.Incremental(delay, 0)`.
+//
+// The Policy's strategies are evaluated in the goroutine of each attempt, one
+// attempt at a time and in order, so a strategy that waits delays its attempt
+// without delaying the others. An error marked as Permanent, or that the
+// Policy's classifiers determine shouldn't be retried, halts the hedging
+// process and is returned. The Policy's hooks are called after every attempt,
+// one at a time, in the order that the attempts complete.
+//
+// If a strategy halts the hedging process and every attempt made has failed,
+// the last error returned by the action is returned, just as with DoContext. If
+// the given context is done first, the context's error is returned.
+func (p *Policy) Hedge(ctx context.Context, action ContextAction, delay backoff.Algorithm, maxInFlight uint) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if maxInFlight < 1 {
+		maxInFlight = 1
+	}
+
+	evaluators := p.newEvaluators()
+
+	c := p.clock
+
+	if c == nil {
+		c = clock.System()
+	}
+
+	perform := func(attempt uint) error {
+		return action(ctx, attempt)
+	}
+
+	if p.recover {
+		perform = Recover(perform)
+	}
+
+	results := make(chan hedgeResult)
+
+	// Each attempt evaluates the strategies once the previous attempt has, so
+	// that they're evaluated one attempt at a time, in order
+	evaluated := make(chan struct{})
+	close(evaluated)
+
+	// halted is only accessed by attempts, in order, while evaluating
+	var halted bool
+
+	var hedge <-chan time.Time
+
+	var launched, inFlight uint
+
+	launch := func() {
+		previous := evaluated
+		evaluated = make(chan struct{})
+
+		go func(number uint, previous <-chan struct{}, evaluated chan<- struct{}) {
+			result := hedgeResult{halting: -1}
+
+			select {
+			case <-previous:
+				if halted {
+					result.skipped = true
+				} else {
+					result.halting = haltingEvaluator(ctx, c, number, evaluators)
+					halted = result.halting >= 0
+				}
+
+				close(evaluated)
+			case <-ctx.Done():
+				return
+			}
+
+			if !result.skipped && result.halting < 0 {
+				start := p.now()
+				err := perform(number + 1)
+
+				result.attempt = Attempt{
+					Number:   number + 1,
+					Err:      err,
+					Start:    start,
+					Duration: p.now().Sub(start),
+				}
+			}
+
+			select {
+			case results <- result:
+			case <-ctx.Done():
+			}
+		}(launched, previous, evaluated)
+
+		launched++
+		inFlight++
+
+		hedge = c.After(delay(launched))
+	}
+
+	var pending, exhausted bool
+	var attempts uint
+	var lastErr error
+
+	halting := -1
+
+	launch()
+
+	for !exhausted || inFlight > 0 {
+		select {
+		case <-hedge:
+			hedge = nil
+			pending = true
+		case result := <-results:
+			inFlight--
+
+			switch {
+			case result.skipped:
+			case result.halting >= 0:
+				exhausted = true
+				halting = result.halting
+			default:
+				p.notify(result.attempt)
+
+				if err := result.attempt.Err; err == nil || IsPermanent(err) || !p.shouldRetry(err) {
+					return err
+				}
+
+				attempts++
+				lastErr = result.attempt.Err
+			}
+		case <-ctx.Done():
+		}
+
+		// Strategies halt once the context is done, so check it first
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if pending && !exhausted && inFlight < maxInFlight {
+			pending = false
+			launch()
+		}
+	}
+
+	if p.exhausted && lastErr != nil {
+		return &ExhaustedError{
+			Err:         lastErr,
+			Attempts:    attempts,
+			Strategy:    halting,
+			Description: evaluators[halting].strategy.String(),
+		}
+	}
+
+	return lastErr
+}
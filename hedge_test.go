@@ -0,0 +1,315 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Rican7/retry"
+	"github.com/Rican7/retry/backoff"
+	"github.com/Rican7/retry/retrytest"
+	"github.com/Rican7/retry/strategy"
+)
+
+func TestHedge(t *testing.T) {
+	action := func(ctx context.Context, attempt uint) error {
+		return nil
+	}
+
+	err := retry.Hedge(context.Background(), action, backoff.Incremental(time.Second, 0), 1)
+
+	if err != nil {
+		t.Error("expected a nil error")
+	}
+}
+
+func TestHedgeLaunchesParallelAttempts(t *testing.T) {
+	const hedgeDelay = time.Millisecond
+
+	var cancelledAttempts int32
+
+	action := func(ctx context.Context, attempt uint) error {
+		if attempt == 3 {
+			return nil
+		}
+
+		// Slow attempts only complete once cancelled
+		<-ctx.Done()
+		atomic.AddInt32(&cancelledAttempts, 1)
+
+		return ctx.Err()
+	}
+
+	err := retry.Hedge(context.Background(), action, backoff.Incremental(hedgeDelay, 0), 3)
+
+	if err != nil {
+		t.Errorf("expected a nil error, received %q instead", err)
+	}
+
+	// Wait for the slow attempts to observe their cancellation
+	for deadline := time.Now().Add(time.Second); atomic.LoadInt32(&cancelledAttempts) < 2; {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected 2 attempts to be cancelled, but %d were", atomic.LoadInt32(&cancelledAttempts))
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestHedgeRespectsStrategies(t *testing.T) {
+	const attemptLimit = 3
+
+	var attemptsMade uint32
+
+	release := make(chan struct{})
+	errFailed := errors.New("failed")
+
+	action := func(ctx context.Context, attempt uint) error {
+		atomic.AddUint32(&attemptsMade, 1)
+
+		<-release
+
+		return errFailed
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+	}()
+
+	err := retry.Hedge(
+		context.Background(),
+		action,
+		backoff.Incremental(time.Millisecond, 0),
+		attemptLimit,
+		strategy.Limit(attemptLimit),
+	)
+
+	if err != errFailed {
+		t.Errorf("expected the action's error, received %q instead", err)
+	}
+
+	if attemptsMade := atomic.LoadUint32(&attemptsMade); attemptsMade != attemptLimit {
+		t.Errorf("expected %d attempts to be made, but %d were made instead", attemptLimit, attemptsMade)
+	}
+}
+
+func TestHedgeRetriesFailures(t *testing.T) {
+	action := func(ctx context.Context, attempt uint) error {
+		if attempt < 5 {
+			return errors.New("erroring")
+		}
+
+		return nil
+	}
+
+	err := retry.Hedge(context.Background(), action, backoff.Incremental(time.Millisecond, 0), 1)
+
+	if err != nil {
+		t.Errorf("expected a nil error, received %q instead", err)
+	}
+}
+
+func TestHedgeBoundsInFlight(t *testing.T) {
+	const maxInFlight = 2
+
+	var inFlight, maxSeen int32
+
+	action := func(ctx context.Context, attempt uint) error {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			seen := atomic.LoadInt32(&maxSeen)
+
+			if current <= seen || atomic.CompareAndSwapInt32(&maxSeen, seen, current) {
+				break
+			}
+		}
+
+		if attempt == 6 {
+			return nil
+		}
+
+		select {
+		case <-time.After(5 * time.Millisecond):
+		case <-ctx.Done():
+		}
+
+		return errors.New("erroring")
+	}
+
+	err := retry.Hedge(context.Background(), action, backoff.Incremental(time.Millisecond, 0), maxInFlight)
+
+	if err != nil {
+		t.Errorf("expected a nil error, received %q instead", err)
+	}
+
+	if seen := atomic.LoadInt32(&maxSeen); seen > maxInFlight {
+		t.Errorf("expected at most %d attempts in flight, but %d were", maxInFlight, seen)
+	}
+}
+
+func TestHedgeWaitsBetweenFailures(t *testing.T) {
+	const hedgeDelay = 10 * time.Millisecond
+
+	var attemptsMade uint32
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*hedgeDelay)
+	defer cancel()
+
+	action := func(ctx context.Context, attempt uint) error {
+		atomic.AddUint32(&attemptsMade, 1)
+
+		return errors.New("erroring")
+	}
+
+	err := retry.Hedge(ctx, action, backoff.Incremental(hedgeDelay, 0), 1)
+
+	if err != context.DeadlineExceeded {
+		t.Errorf("expected a deadline exceeded error, received %q instead", err)
+	}
+
+	// A failing action is retried after the delay, rather than in a busy loop
+	if attemptsMade := atomic.LoadUint32(&attemptsMade); attemptsMade > 6 {
+		t.Errorf("expected at most 6 attempts to be made, but %d were made instead", attemptsMade)
+	}
+}
+
+func TestHedgeWaitingStrategyDoesNotBlock(t *testing.T) {
+	action := func(ctx context.Context, attempt uint) error {
+		if attempt == 1 {
+			time.Sleep(10 * time.Millisecond)
+
+			return nil
+		}
+
+		return errors.New("erroring")
+	}
+
+	start := time.Now()
+
+	// The second attempt waits for an hour, without delaying the first
+	err := retry.Hedge(context.Background(), action, backoff.Incremental(time.Millisecond, 0), 2, strategy.Wait(time.Hour))
+
+	if err != nil {
+		t.Errorf("expected a nil error, received %q instead", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the first attempt to win promptly, but it took %s", elapsed)
+	}
+}
+
+func TestHedgeContextDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	action := func(ctx context.Context, attempt uint) error {
+		<-ctx.Done()
+
+		return errors.New("too late")
+	}
+
+	err := retry.Hedge(ctx, action, backoff.Incremental(time.Millisecond, 0), 3, strategy.Limit(3))
+
+	if err != context.DeadlineExceeded {
+		t.Errorf("expected a deadline exceeded error, received %q instead", err)
+	}
+}
+
+func TestHedgeNoAttempts(t *testing.T) {
+	var attemptsMade uint32
+
+	action := func(ctx context.Context, attempt uint) error {
+		atomic.AddUint32(&attemptsMade, 1)
+
+		return errors.New("erroring")
+	}
+
+	err := retry.Hedge(context.Background(), action, backoff.Incremental(time.Millisecond, 0), 1, strategy.Limit(0))
+
+	if err != nil {
+		t.Errorf("expected a nil error, received %q instead", err)
+	}
+
+	if atomic.LoadUint32(&attemptsMade) != 0 {
+		t.Error("expected no attempts to be made")
+	}
+}
+
+func TestPolicyHedge(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	clock := retrytest.NewClock(time.Now())
+	recorder := retrytest.NewRecorder(clock)
+
+	policy := retry.NewPolicy(retry.WithClock(clock), retry.WithHooks(recorder.Hook()))
+
+	action := func(ctx context.Context, attempt uint) error {
+		if attempt == 2 {
+			return nil
+		}
+
+		// The first attempt only completes once cancelled
+		<-ctx.Done()
+
+		return ctx.Err()
+	}
+
+	// The second attempt is only started once the Policy's clock has waited
+	err := policy.Hedge(ctx, action, backoff.Incremental(time.Hour, 0), 2)
+
+	if err != nil {
+		t.Errorf("expected a nil error, received %q instead", err)
+	}
+
+	recorder.AssertAttempts(t, 1)
+}
+
+func TestPolicyHedgeClassifiers(t *testing.T) {
+	errInvalid := errors.New("invalid")
+
+	var attemptsMade uint32
+
+	policy := retry.NewPolicy(retry.WithClassifiers(func(err error) bool {
+		return !errors.Is(err, errInvalid)
+	}))
+
+	err := policy.Hedge(context.Background(), func(ctx context.Context, attempt uint) error {
+		atomic.AddUint32(&attemptsMade, 1)
+
+		return errInvalid
+	}, backoff.Incremental(time.Millisecond, 0), 1)
+
+	if err != errInvalid {
+		t.Errorf("expected the invalid error, received %q instead", err)
+	}
+
+	if attemptsMade := atomic.LoadUint32(&attemptsMade); attemptsMade != 1 {
+		t.Errorf("expected 1 attempt to be made, but %d were made instead", attemptsMade)
+	}
+}
+
+func TestPolicyHedgeExhaustedError(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	policy := retry.NewPolicy(retry.WithStrategies(strategy.Limit(2)), retry.WithExhaustedError())
+
+	err := policy.Hedge(context.Background(), func(ctx context.Context, attempt uint) error {
+		return errFailed
+	}, backoff.Incremental(time.Millisecond, 0), 1)
+
+	var exhaustedErr *retry.ExhaustedError
+
+	if !errors.As(err, &exhaustedErr) || !errors.Is(err, errFailed) {
+		t.Fatalf("expected an exhausted error wrapping the action's error, received %q instead", err)
+	}
+
+	if exhaustedErr.Attempts != 2 || exhaustedErr.Description != "Limit(2)" {
+		t.Errorf("expected 2 attempts halted by Limit(2), received %q instead", err)
+	}
+}
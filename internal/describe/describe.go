@@ -0,0 +1,86 @@
+// Package describe provides a way to describe values by the call of the
+// constructor that created them, such as "Limit(5)".
+//
+// Copyright © 2026 Trevor N. Suarez (Rican7)
+package describe
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Constructor is the call of the constructor that created a value, made with
+// the given arguments. If the value was created by a method of what the
+// constructor created, that method is named too, such as with
+// "NewAdaptive(10ms, 1s, 2, 1m0s).Strategy()".
+type Constructor struct {
+	Name   string
+	Args   []any
+	Method string
+}
+
+// String describes the call of the constructor, as Call does, followed by the
+// call of the method, if any.
+func (c Constructor) String() string {
+	if c.Method == "" {
+		return Call(c.Name, c.Args...)
+	}
+
+	return Call(c.Name, c.Args...) + "." + c.Method + "()"
+}
+
+// Call describes a call of the named constructor with the given arguments,
+// such as "Exponential(10ms, 2)". A call without arguments is described by the
+// name alone.
+//
+// Arguments that describe themselves (as a fmt.Stringer) are described as
+// such, strings are quoted, and other basic values are formatted as is. Any
+// other argument is described by its type.
+func Call(name string, args ...any) string {
+	if len(args) == 0 {
+		return name
+	}
+
+	descriptions := make([]string, len(args))
+
+	for i, arg := range args {
+		descriptions[i] = argument(arg)
+	}
+
+	return name + "(" + strings.Join(descriptions, ", ") + ")"
+}
+
+// argument describes a single argument of a constructor call.
+func argument(arg any) string {
+	switch arg := arg.(type) {
+	case nil:
+		return "<nil>"
+	case fmt.Stringer:
+		return arg.String()
+	case string:
+		return fmt.Sprintf("%q", arg)
+	}
+
+	switch reflect.ValueOf(arg).Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprint(arg)
+	}
+
+	return fmt.Sprintf("%T", arg)
+}
+
+// Args converts the given values to arguments, for a constructor that accepts
+// a variadic number of them.
+func Args[T any](values []T) []any {
+	args := make([]any, len(values))
+
+	for i, value := range values {
+		args[i] = value
+	}
+
+	return args
+}
@@ -0,0 +1,40 @@
+package describe
+
+import (
+	"testing"
+	"time"
+)
+
+type named struct{}
+
+func (named) String() string {
+	return "Named"
+}
+
+func TestCall(t *testing.T) {
+	descriptions := map[string]string{
+		"Limit":                             Call("Limit"),
+		"Limit(5)":                          Call("Limit", uint(5)),
+		"Exponential(10ms, 2)":              Call("Exponential", 10*time.Millisecond, 2.0),
+		"Wrap(Named, Named)":                Call("Wrap", named{}, &named{}),
+		`Keyed("host-1")`:                   Call("Keyed", "host-1"),
+		"Flag(true, -1)":                    Call("Flag", true, -1),
+		"Limiter(*describe.limiter, <nil>)": Call("Limiter", &limiter{}, nil),
+	}
+
+	for expected, description := range descriptions {
+		if description != expected {
+			t.Errorf("expected the description %q, received %q instead", expected, description)
+		}
+	}
+}
+
+type limiter struct {
+	tokens int
+}
+
+func TestArgs(t *testing.T) {
+	if description := Call("Max", Args([]time.Duration{time.Second, time.Minute})...); description != "Max(1s, 1m0s)" {
+		t.Errorf("expected the description %q, received %q instead", "Max(1s, 1m0s)", description)
+	}
+}
@@ -0,0 +1,70 @@
+package describe
+
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// Registry associates functions, of the func type F, with values of type V,
+// such as the Constructor that describes them. It's safe for concurrent use,
+// and looking up a function never blocks.
+//
+// A function is identified by its func value, which points to the closure that
+// it was created as. As such, only closures that capture variables may be
+// registered, as those are created anew every time, so that each is identified
+// uniquely. A registered function is forgotten once it's garbage collected.
+type Registry[F, V any] struct {
+	values sync.Map // map[uintptr]V, keyed by the address of each closure
+}
+
+// closure is the start of the object that a func value points to.
+type closure struct {
+	code uintptr
+}
+
+// closureOf returns the closure that the given func value points to.
+func closureOf[F any](fn F) *closure {
+	return *(**closure)(unsafe.Pointer(&fn))
+}
+
+// Register associates the given function with the given value, and returns the
+// function. The function must be a closure that captures variables, and must
+// only be registered once.
+func (r *Registry[F, V]) Register(fn F, value V) F {
+	c := closureOf(fn)
+
+	if c == nil {
+		return fn
+	}
+
+	// The closure's address is kept rather than the closure itself, so that the
+	// closure may still be garbage collected, at which point it's forgotten
+	key := uintptr(unsafe.Pointer(c))
+
+	r.values.Store(key, value)
+
+	runtime.SetFinalizer(c, func(*closure) {
+		r.values.Delete(key)
+	})
+
+	return fn
+}
+
+// Lookup returns the value associated with the given function, with ok set to
+// `true`. For a function that isn't registered, ok is `false`.
+func (r *Registry[F, V]) Lookup(fn F) (value V, ok bool) {
+	c := closureOf(fn)
+
+	if c == nil {
+		return value, false
+	}
+
+	v, ok := r.values.Load(uintptr(unsafe.Pointer(c)))
+
+	if !ok {
+		return value, false
+	}
+
+	return v.(V), true
+}
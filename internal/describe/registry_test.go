@@ -0,0 +1,66 @@
+package describe
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+// adder creates a function that adds the given amount, as a closure that
+// captures it.
+func adder(amount int) func(int) int {
+	return func(n int) int {
+		return n + amount
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	var registry Registry[func(int) int, string]
+
+	one := registry.Register(adder(1), "one")
+	two := registry.Register(adder(2), "two")
+
+	for expected, fn := range map[string]func(int) int{"one": one, "two": two} {
+		if value, ok := registry.Lookup(fn); !ok || value != expected {
+			t.Errorf("expected the value %q, received %q (ok: %t) instead", expected, value, ok)
+		}
+	}
+
+	if result := two(1); result != 3 {
+		t.Errorf("registered function expected to return 3, but received %d instead", result)
+	}
+
+	for _, fn := range []func(int) int{adder(1), func(n int) int { return n }, nil} {
+		if value, ok := registry.Lookup(fn); ok {
+			t.Errorf("expected no value, received %q instead", value)
+		}
+	}
+}
+
+func TestRegistryForgetsCollectedFunctions(t *testing.T) {
+	var registry Registry[func(int) int, int]
+
+	for i := 0; i < 100; i++ {
+		registry.Register(adder(i), i)
+	}
+
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); {
+		runtime.GC()
+
+		remaining := 0
+
+		registry.values.Range(func(_, _ any) bool {
+			remaining++
+
+			return true
+		})
+
+		if remaining == 0 {
+			return
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Error("registry expected to forget the functions once collected")
+}
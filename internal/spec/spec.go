@@ -0,0 +1,41 @@
+// Package spec provides what evaluates and describes each strategy created by
+// package strategy, so that package retry may evaluate those strategies with a
+// context and a clock directly.
+//
+// Copyright © 2026 Trevor N. Suarez (Rican7)
+package spec
+
+import (
+	"context"
+
+	"github.com/Rican7/retry/clock"
+	"github.com/Rican7/retry/internal/describe"
+)
+
+// Evaluator defines a function that evaluates a strategy for the given attempt
+// number, waiting on the given clock and halting once the given context is
+// done.
+type Evaluator func(ctx context.Context, c clock.Clock, attempt uint) bool
+
+// Spec is what evaluates and describes a strategy created by package strategy.
+type Spec struct {
+	Evaluate Evaluator
+	describe.Constructor
+}
+
+// specs holds the Spec of each strategy created by package strategy.
+var specs describe.Registry[func(attempt uint) bool, *Spec]
+
+// Register associates the given strategy with the given Spec, and returns the
+// strategy. The strategy must be a closure that captures variables.
+func Register(strategy func(attempt uint) bool, spec *Spec) func(attempt uint) bool {
+	return specs.Register(strategy, spec)
+}
+
+// Of returns the Spec of the given strategy, or nil if it wasn't created by
+// package strategy.
+func Of(strategy func(attempt uint) bool) *Spec {
+	spec, _ := specs.Lookup(strategy)
+
+	return spec
+}
@@ -0,0 +1,57 @@
+package jitter
+
+import (
+	"time"
+
+	"github.com/Rican7/retry/internal/describe"
+)
+
+// None creates a Transformation that simply returns the input duration.
+func None() Transformation {
+	return newTransformation(func(duration time.Duration) time.Duration {
+		return duration
+	}, "None")
+}
+
+// Chain creates a Transformation that applies each of the given
+// transformations in order, passing the result of each to the next.
+func Chain(transformations ...Transformation) Transformation {
+	return newTransformation(func(duration time.Duration) time.Duration {
+		for _, transformation := range transformations {
+			duration = transformation(duration)
+		}
+
+		return duration
+	}, "Chain", describe.Args(transformations)...)
+}
+
+// Clamp creates a Transformation that limits the result of the given
+// transformation to [min, max]. For example, a transformation may be kept from
+// returning negative durations with `Clamp(transformation, 0, max)`.
+func Clamp(transformation Transformation, min, max time.Duration) Transformation {
+	return newTransformation(func(duration time.Duration) time.Duration {
+		return clamp(transformation(duration), min, max)
+	}, "Clamp", transformation, min, max)
+}
+
+// Bound creates a Transformation that limits the result of the given
+// transformation to deviate from the input duration by no more than the given
+// maximum deviation, that is, to [n-maxDeviation, n+maxDeviation], where n is
+// the input duration.
+func Bound(transformation Transformation, maxDeviation time.Duration) Transformation {
+	return newTransformation(func(duration time.Duration) time.Duration {
+		return clamp(transformation(duration), duration-maxDeviation, duration+maxDeviation)
+	}, "Bound", transformation, maxDeviation)
+}
+
+// clamp limits the given duration to [min, max].
+func clamp(duration, min, max time.Duration) time.Duration {
+	switch {
+	case duration < min:
+		return min
+	case duration > max:
+		return max
+	}
+
+	return duration
+}
@@ -0,0 +1,115 @@
+package jitter
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestNone(t *testing.T) {
+	transformation := None()
+
+	for i := 0; i < 10; i++ {
+		duration := time.Duration(i) * time.Millisecond
+		result := transformation(duration)
+		expected := duration
+
+		if result != expected {
+			t.Errorf("transformation expected to return a %s duration, but received %s instead", expected, result)
+		}
+	}
+}
+
+func TestChain(t *testing.T) {
+	const duration = time.Millisecond
+
+	double := func(duration time.Duration) time.Duration {
+		return duration * 2
+	}
+
+	subtract := func(duration time.Duration) time.Duration {
+		return duration - time.Microsecond
+	}
+
+	transformations := map[time.Duration]Transformation{
+		duration:                          Chain(),
+		(duration * 2) - time.Microsecond: Chain(double, subtract),
+		(duration - time.Microsecond) * 2: Chain(subtract, double),
+	}
+
+	for expected, transformation := range transformations {
+		if result := transformation(duration); result != expected {
+			t.Errorf("transformation expected to return a %s duration, but received %s instead", expected, result)
+		}
+	}
+}
+
+func TestClamp(t *testing.T) {
+	const min = time.Millisecond
+	const max = 3 * time.Millisecond
+
+	transformation := Clamp(None(), min, max)
+
+	inputs := map[time.Duration]time.Duration{
+		-time.Millisecond:    min,
+		0:                    min,
+		min:                  min,
+		2 * time.Millisecond: 2 * time.Millisecond,
+		max:                  max,
+		time.Hour:            max,
+	}
+
+	for input, expected := range inputs {
+		if result := transformation(input); result != expected {
+			t.Errorf("transformation expected to return a %s duration, but received %s instead", expected, result)
+		}
+	}
+}
+
+func TestClampNormalDistribution(t *testing.T) {
+	const seed = 0
+	const duration = time.Millisecond
+	const max = 2 * duration
+
+	generator := rand.New(rand.NewSource(seed))
+
+	transformation := Clamp(NormalDistribution(generator, float64(10*duration)), 0, max)
+
+	for i := 0; i < 1000; i++ {
+		if result := transformation(duration); result < 0 || result > max {
+			t.Fatalf("transformation expected to return a duration in [0, %s], but received %s instead", max, result)
+		}
+	}
+}
+
+func TestBound(t *testing.T) {
+	const seed = 0
+	const duration = time.Second
+	const maxDeviation = time.Millisecond
+
+	generator := rand.New(rand.NewSource(seed))
+
+	transformation := Bound(NormalDistribution(generator, float64(time.Second)), maxDeviation)
+
+	var bounded int
+
+	for i := 0; i < 1000; i++ {
+		result := transformation(duration)
+
+		if result < duration-maxDeviation || result > duration+maxDeviation {
+			t.Fatalf("transformation expected to return a duration within %s of %s, but received %s instead", maxDeviation, duration, result)
+		}
+
+		if result == duration-maxDeviation || result == duration+maxDeviation {
+			bounded++
+		}
+	}
+
+	if bounded == 0 {
+		t.Error("transformation expected to bound some of the durations")
+	}
+
+	if result := Bound(None(), maxDeviation)(duration); result != duration {
+		t.Errorf("transformation expected to return a %s duration, but received %s instead", duration, result)
+	}
+}
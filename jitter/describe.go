@@ -0,0 +1,38 @@
+package jitter
+
+import (
+	"time"
+
+	"github.com/Rican7/retry/internal/describe"
+)
+
+// constructors holds what describes each Transformation created by this
+// package.
+var constructors describe.Registry[Transformation, describe.Constructor]
+
+// newTransformation creates a Transformation that transforms durations with
+// the given transformation, and that's described by the given constructor name
+// and arguments.
+func newTransformation(transformation Transformation, name string, args ...any) Transformation {
+	return constructors.Register(func(duration time.Duration) time.Duration {
+		return transformation(duration)
+	}, describe.Constructor{Name: name, Args: args})
+}
+
+// String describes the Transformation by the call of the constructor that
+// created it, such as "Deviation(0.5)". Random generators aren't described. A
+// Transformation that wasn't created by this package is described as
+// "<custom>".
+func (t Transformation) String() string {
+	if t == nil {
+		return "<nil>"
+	}
+
+	constructor, ok := constructors.Lookup(t)
+
+	if !ok {
+		return "<custom>"
+	}
+
+	return constructor.String()
+}
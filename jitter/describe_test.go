@@ -0,0 +1,32 @@
+package jitter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTransformationString(t *testing.T) {
+	transformations := map[string]Transformation{
+		"Full":                      Full(nil),
+		"Equal":                     Equal(nil),
+		`Keyed("host-1")`:           Keyed("host-1"),
+		"Deviation(0.5)":            Deviation(nil, 0.5),
+		"NormalDistribution(1e+06)": NormalDistribution(nil, float64(time.Millisecond)),
+		"Uniform(1ms, 2ms)":         Uniform(nil, time.Millisecond, 2*time.Millisecond),
+		"Exponential(2)":            Exponential(nil, 2),
+		"LogNormal(0.25)":           LogNormal(nil, 0.25),
+		"None":                      None(),
+		"Chain(Equal, <custom>)": Chain(Equal(nil), func(duration time.Duration) time.Duration {
+			return duration
+		}),
+		"Clamp(Full, 1ms, 1s)": Clamp(Full(nil), time.Millisecond, time.Second),
+		"Bound(Full, 1ms)":     Bound(Full(nil), time.Millisecond),
+		"<nil>":                nil,
+	}
+
+	for expected, transformation := range transformations {
+		if description := transformation.String(); description != expected {
+			t.Errorf("expected the description %q, received %q instead", expected, description)
+		}
+	}
+}
@@ -1,11 +1,19 @@
 // Package jitter provides methods of transforming durations.
 //
+// The transformations created by this package are safe for concurrent use,
+// unless they're given a random generator that isn't. The default generators
+// that they're provided when given a nil generator are safe, while a *rand.Rand
+// created with rand.New isn't, so such a generator must not be shared by
+// transformations that are used concurrently.
+//
 // Copyright © 2016 Trevor N. Suarez (Rican7)
 package jitter
 
 import (
+	"hash/fnv"
 	"math"
 	"math/rand"
+	"sync"
 	"time"
 )
 
@@ -14,7 +22,8 @@ import (
 type Transformation func(duration time.Duration) time.Duration
 
 // Full creates a Transformation that transforms a duration into a result
-// duration in [0, n) randomly, where n is the given duration.
+// duration in [0, n) randomly, where n is the given duration. A duration that
+// isn't positive is returned as is.
 //
 // The given generator is what is used to determine the random transformation.
 // If a nil generator is passed, a default one will be provided.
@@ -23,13 +32,18 @@ type Transformation func(duration time.Duration) time.Duration
 func Full(generator *rand.Rand) Transformation {
 	random := fallbackNewRandom(generator)
 
-	return func(duration time.Duration) time.Duration {
+	return newTransformation(func(duration time.Duration) time.Duration {
+		if duration <= 0 {
+			return duration
+		}
+
 		return time.Duration(random.Int63n(int64(duration)))
-	}
+	}, "Full")
 }
 
 // Equal creates a Transformation that transforms a duration into a result
-// duration in [n/2, n) randomly, where n is the given duration.
+// duration in [n/2, n) randomly, where n is the given duration. A duration that
+// isn't positive is returned as is.
 //
 // The given generator is what is used to determine the random transformation.
 // If a nil generator is passed, a default one will be provided.
@@ -38,13 +52,31 @@ func Full(generator *rand.Rand) Transformation {
 func Equal(generator *rand.Rand) Transformation {
 	random := fallbackNewRandom(generator)
 
-	return func(duration time.Duration) time.Duration {
+	return newTransformation(func(duration time.Duration) time.Duration {
+		if duration <= 0 {
+			return duration
+		}
+
 		return (duration / 2) + time.Duration(random.Int63n(int64(duration))/2)
-	}
+	}, "Equal")
+}
+
+// Keyed creates a Transformation that transforms a duration into a result
+// duration in [0, n), where n is the given duration, as determined by the given
+// key, such as a hostname, request ID, or shard number. The same key always
+// results in the same fraction of the duration, while different keys are spread
+// evenly across the range, without needing a shared random source.
+func Keyed(key string) Transformation {
+	fraction := keyFraction(key)
+
+	return newTransformation(func(duration time.Duration) time.Duration {
+		return time.Duration(fraction * float64(duration))
+	}, "Keyed", key)
 }
 
 // Deviation creates a Transformation that transforms a duration into a result
-// duration that deviates from the input randomly by a given factor.
+// duration that deviates from the input randomly by a given factor. A duration
+// that can't deviate by the factor, such as a zero duration, is returned as is.
 //
 // The given generator is what is used to determine the random transformation.
 // If a nil generator is passed, a default one will be provided.
@@ -53,12 +85,16 @@ func Equal(generator *rand.Rand) Transformation {
 func Deviation(generator *rand.Rand, factor float64) Transformation {
 	random := fallbackNewRandom(generator)
 
-	return func(duration time.Duration) time.Duration {
+	return newTransformation(func(duration time.Duration) time.Duration {
 		min := int64(math.Floor(float64(duration) * (1 - factor)))
 		max := int64(math.Ceil(float64(duration) * (1 + factor)))
 
+		if max <= min {
+			return duration
+		}
+
 		return time.Duration(random.Int63n(max-min) + min)
-	}
+	}, "Deviation", factor)
 }
 
 // NormalDistribution creates a Transformation that transforms a duration into a
@@ -70,13 +106,137 @@ func Deviation(generator *rand.Rand, factor float64) Transformation {
 func NormalDistribution(generator *rand.Rand, standardDeviation float64) Transformation {
 	random := fallbackNewRandom(generator)
 
-	return func(duration time.Duration) time.Duration {
+	return newTransformation(func(duration time.Duration) time.Duration {
 		return time.Duration(random.NormFloat64()*standardDeviation + float64(duration))
+	}, "NormalDistribution", standardDeviation)
+}
+
+// Uniform creates a Transformation that transforms a duration into a result
+// duration in [n+min, n+max) randomly, where n is the given duration, by adding
+// a uniformly distributed random duration. If max isn't greater than min, the
+// result is always n+min. The result is limited to the representable durations,
+// rather than overflowing.
+//
+// The given generator is what is used to determine the random transformation.
+// If a nil generator is passed, a default one will be provided.
+func Uniform(generator *rand.Rand, min, max time.Duration) Transformation {
+	random := fallbackNewRandom(generator)
+
+	return newTransformation(func(duration time.Duration) time.Duration {
+		if max <= min {
+			return addDuration(duration, min)
+		}
+
+		// The span may not fit in a signed duration, but its offset from min
+		// always wraps back into range
+		offset := min + time.Duration(randomUint64n(random, uint64(max-min)))
+
+		return addDuration(duration, offset)
+	}, "Uniform", min, max)
+}
+
+// Exponential creates a Transformation that transforms a duration into a result
+// duration in [n, +Inf) randomly, where n is the given duration, by adding an
+// exponentially distributed random duration with a mean of n/rate. Smaller
+// rates spread the results further. A rate that isn't positive spreads nothing,
+// so the given duration is returned as is.
+//
+// The given generator is what is used to determine the random transformation.
+// If a nil generator is passed, a default one will be provided.
+func Exponential(generator *rand.Rand, rate float64) Transformation {
+	random := fallbackNewRandom(generator)
+
+	return newTransformation(func(duration time.Duration) time.Duration {
+		if !(rate > 0) {
+			return duration
+		}
+
+		return toDuration(float64(duration) + (float64(duration) * random.ExpFloat64() / rate))
+	}, "Exponential", rate)
+}
+
+// LogNormal creates a Transformation that transforms a duration into a result
+// duration in (0, +Inf) randomly, by multiplying the given duration by a
+// log-normally distributed random factor, whose logarithm has a mean of 0 and
+// the given standard deviation (sigma). As such, the median of the results is
+// the given duration.
+//
+// The given generator is what is used to determine the random transformation.
+// If a nil generator is passed, a default one will be provided.
+func LogNormal(generator *rand.Rand, sigma float64) Transformation {
+	random := fallbackNewRandom(generator)
+
+	return newTransformation(func(duration time.Duration) time.Duration {
+		return toDuration(float64(duration) * math.Exp(sigma*random.NormFloat64()))
+	}, "LogNormal", sigma)
+}
+
+// toDuration converts the given number of nanoseconds to a time.Duration,
+// limiting it to the representable durations, rather than overflowing. NaN is
+// converted to a zero duration.
+func toDuration(nanoseconds float64) time.Duration {
+	switch {
+	case math.IsNaN(nanoseconds):
+		return 0
+	case nanoseconds >= math.MaxInt64:
+		return math.MaxInt64
+	case nanoseconds <= math.MinInt64:
+		return math.MinInt64
+	}
+
+	return time.Duration(nanoseconds)
+}
+
+// addDuration adds the given durations, limiting the sum to the representable
+// durations, rather than overflowing.
+func addDuration(a, b time.Duration) time.Duration {
+	sum := a + b
+
+	switch {
+	case b > 0 && sum < a:
+		return math.MaxInt64
+	case b < 0 && sum > a:
+		return math.MinInt64
+	}
+
+	return sum
+}
+
+// randomUint64n returns a uniformly distributed random integer in [0, n) from
+// the given generator. n must be greater than 0.
+func randomUint64n(random *rand.Rand, n uint64) uint64 {
+	if n <= math.MaxInt64 {
+		return uint64(random.Int63n(int64(n)))
+	}
+
+	// More than half of all values are in range, so rejecting the rest
+	// rarely takes more than a few tries
+	for {
+		if value := random.Uint64(); value < n {
+			return value
+		}
 	}
 }
 
+// keyFraction hashes the given key into a fraction in [0, 1).
+func keyFraction(key string) float64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(key))
+
+	// Mix the hash's bits (using the SplitMix64 finalizer), as FNV alone
+	// doesn't spread similar keys evenly
+	sum := hash.Sum64()
+	sum = (sum ^ (sum >> 30)) * 0xbf58476d1ce4e5b9
+	sum = (sum ^ (sum >> 27)) * 0x94d049bb133111eb
+	sum ^= sum >> 31
+
+	// Use the top 53 bits, as that's the precision of a float64
+	return float64(sum>>11) / (1 << 53)
+}
+
 // fallbackNewRandom returns the passed in random instance if it's not nil,
-// and otherwise returns a new random instance seeded with the current time.
+// and otherwise returns a new random instance seeded with the current time,
+// which is safe for concurrent use.
 func fallbackNewRandom(random *rand.Rand) *rand.Rand {
 	// Return the passed in value if it's already not null
 	if random != nil {
@@ -85,5 +245,36 @@ func fallbackNewRandom(random *rand.Rand) *rand.Rand {
 
 	seed := time.Now().UnixNano()
 
-	return rand.New(rand.NewSource(seed))
+	return rand.New(&lockedSource{source: rand.NewSource(seed).(rand.Source64)})
+}
+
+// lockedSource is a rand.Source that's safe for concurrent use, as the sources
+// created by package rand aren't.
+type lockedSource struct {
+	mutex  sync.Mutex
+	source rand.Source64
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer.
+func (s *lockedSource) Int63() int64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.source.Int63()
+}
+
+// Uint64 returns a pseudo-random 64-bit integer.
+func (s *lockedSource) Uint64() uint64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.source.Uint64()
+}
+
+// Seed uses the given seed value to initialize the source.
+func (s *lockedSource) Seed(seed int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.source.Seed(seed)
 }
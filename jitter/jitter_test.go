@@ -1,7 +1,10 @@
 package jitter
 
 import (
+	"fmt"
+	"math"
 	"math/rand"
+	"sync"
 	"testing"
 	"time"
 )
@@ -46,6 +49,75 @@ func TestEqual(t *testing.T) {
 	}
 }
 
+func TestNonPositiveDurations(t *testing.T) {
+	transformations := []Transformation{
+		Full(nil),
+		Equal(nil),
+		Deviation(nil, 0.5),
+		Deviation(nil, 0),
+	}
+
+	for _, transformation := range transformations {
+		for _, duration := range []time.Duration{0, -time.Millisecond} {
+			if result := transformation(duration); result != duration {
+				t.Errorf("transformation %s expected to return a %s duration, but received %s instead", transformation, duration, result)
+			}
+		}
+	}
+}
+
+func TestKeyed(t *testing.T) {
+	const duration = time.Millisecond
+
+	keys := []string{"host-1", "host-2", "8a9f2c1e-request", "shard-42", ""}
+
+	for _, key := range keys {
+		expected := Keyed(key)(duration)
+
+		if expected < 0 || expected >= duration {
+			t.Errorf("transformation expected to return a duration in [0, %s), but received %s instead", duration, expected)
+		}
+
+		// The same key always results in the same duration
+		for i := 0; i < 10; i++ {
+			if result := Keyed(key)(duration); result != expected {
+				t.Errorf("transformation expected to return a %s duration for key %q, but received %s instead", expected, key, result)
+			}
+		}
+
+		if result := Keyed(key)(0); result != 0 {
+			t.Errorf("transformation expected to return a 0 duration, but received %s instead", result)
+		}
+	}
+
+	if Keyed("host-1")(duration) == Keyed("host-2")(duration) {
+		t.Error("transformation expected to return different durations for different keys")
+	}
+}
+
+func TestKeyedSpread(t *testing.T) {
+	const keyCount = 10000
+	const bucketCount = 10
+	const duration = time.Second
+
+	buckets := make([]int, bucketCount)
+
+	for i := 0; i < keyCount; i++ {
+		result := Keyed(fmt.Sprintf("host-%d", i))(duration)
+
+		buckets[int(result*bucketCount/duration)]++
+	}
+
+	// Each bucket is expected to hold an equal share of keys, within 10%
+	const expected = keyCount / bucketCount
+
+	for i, count := range buckets {
+		if math.Abs(float64(count-expected)) > expected/10 {
+			t.Errorf("bucket #%d expected to hold ~%d keys, but held %d instead: %v", i, expected, count, buckets)
+		}
+	}
+}
+
 func TestDeviation(t *testing.T) {
 	const seed = 0
 	const duration = time.Millisecond
@@ -88,6 +160,215 @@ func TestNormalDistribution(t *testing.T) {
 	}
 }
 
+// sampleCount is the number of samples taken to check a distribution.
+const sampleCount = 100000
+
+// moments calculates the mean and standard deviation of the given samples.
+func moments(samples []float64) (mean, standardDeviation float64) {
+	for _, sample := range samples {
+		mean += sample
+	}
+
+	mean /= float64(len(samples))
+
+	for _, sample := range samples {
+		standardDeviation += (sample - mean) * (sample - mean)
+	}
+
+	return mean, math.Sqrt(standardDeviation / float64(len(samples)))
+}
+
+// assertMoments fails the test if the given moments aren't within 2% of the
+// expected moments.
+func assertMoments(t *testing.T, mean, standardDeviation, expectedMean, expectedStandardDeviation float64) {
+	t.Helper()
+
+	const tolerance = 0.02
+
+	if math.Abs(mean-expectedMean) > tolerance*math.Abs(expectedStandardDeviation) {
+		t.Errorf("distribution expected to have a mean of %f, but had %f instead", expectedMean, mean)
+	}
+
+	if math.Abs(standardDeviation-expectedStandardDeviation) > tolerance*expectedStandardDeviation {
+		t.Errorf("distribution expected to have a standard deviation of %f, but had %f instead", expectedStandardDeviation, standardDeviation)
+	}
+}
+
+func TestUniform(t *testing.T) {
+	const seed = 0
+	const duration = time.Second
+	const min = -time.Millisecond
+	const max = 3 * time.Millisecond
+
+	generator := rand.New(rand.NewSource(seed))
+
+	transformation := Uniform(generator, min, max)
+
+	samples := make([]float64, sampleCount)
+
+	for i := range samples {
+		result := transformation(duration)
+
+		if result < duration+min || result >= duration+max {
+			t.Fatalf("transformation expected to return a duration in [%s, %s), but received %s instead", duration+min, duration+max, result)
+		}
+
+		samples[i] = float64(result - duration)
+	}
+
+	mean, standardDeviation := moments(samples)
+
+	assertMoments(t, mean, standardDeviation, float64(min+max)/2, float64(max-min)/math.Sqrt(12))
+
+	if result := Uniform(generator, max, min)(duration); result != duration+max {
+		t.Errorf("transformation expected to return a %s duration, but received %s instead", duration+max, result)
+	}
+}
+
+func TestExponential(t *testing.T) {
+	const seed = 0
+	const duration = time.Second
+	const rate = 4
+
+	generator := rand.New(rand.NewSource(seed))
+
+	transformation := Exponential(generator, rate)
+
+	samples := make([]float64, sampleCount)
+
+	for i := range samples {
+		result := transformation(duration)
+
+		if result < duration {
+			t.Fatalf("transformation expected to return a duration of at least %s, but received %s instead", duration, result)
+		}
+
+		samples[i] = float64(result - duration)
+	}
+
+	mean, standardDeviation := moments(samples)
+
+	// An exponential distribution's mean and standard deviation are equal
+	assertMoments(t, mean, standardDeviation, float64(duration)/rate, float64(duration)/rate)
+}
+
+func TestExponentialNonPositiveRate(t *testing.T) {
+	const duration = time.Second
+
+	for _, rate := range []float64{0, -1, math.Inf(-1), math.NaN()} {
+		if result := Exponential(nil, rate)(duration); result != duration {
+			t.Errorf("transformation with a rate of %v expected to return a %s duration, but received %s instead", rate, duration, result)
+		}
+	}
+}
+
+func TestLogNormal(t *testing.T) {
+	const seed = 0
+	const duration = time.Second
+	const sigma = 0.5
+
+	generator := rand.New(rand.NewSource(seed))
+
+	transformation := LogNormal(generator, sigma)
+
+	samples := make([]float64, sampleCount)
+	logSamples := make([]float64, sampleCount)
+
+	for i := range samples {
+		result := transformation(duration)
+
+		if result <= 0 {
+			t.Fatalf("transformation expected to return a positive duration, but received %s instead", result)
+		}
+
+		samples[i] = float64(result) / float64(duration)
+		logSamples[i] = math.Log(samples[i])
+	}
+
+	mean, standardDeviation := moments(logSamples)
+
+	assertMoments(t, mean, standardDeviation, 0, sigma)
+
+	// The moments of the log-normal distribution itself
+	mean, standardDeviation = moments(samples)
+
+	expectedMean := math.Exp(sigma * sigma / 2)
+	expectedStandardDeviation := math.Sqrt((math.Exp(sigma*sigma) - 1) * math.Exp(sigma*sigma))
+
+	assertMoments(t, mean, standardDeviation, expectedMean, expectedStandardDeviation)
+}
+
+func TestDistributionsOverflow(t *testing.T) {
+	transformations := map[string]Transformation{
+		"Exponential": Exponential(nil, 1e-30),
+		"LogNormal":   LogNormal(nil, 1e30),
+	}
+
+	for name, transformation := range transformations {
+		for i := 0; i < 100; i++ {
+			if result := transformation(time.Hour); result < 0 {
+				t.Fatalf("%s transformation expected to never overflow, but returned %s", name, result)
+			}
+
+			if result := transformation(-time.Hour); result > 0 {
+				t.Fatalf("%s transformation expected to never overflow, but returned %s", name, result)
+			}
+		}
+	}
+}
+
+func TestUniformOverflow(t *testing.T) {
+	const maxDuration = time.Duration(math.MaxInt64)
+	const minDuration = time.Duration(math.MinInt64)
+
+	generator := rand.New(rand.NewSource(0))
+
+	if result := Uniform(generator, time.Hour, 2*time.Hour)(maxDuration); result != maxDuration {
+		t.Errorf("transformation expected to return a %s duration, but received %s instead", maxDuration, result)
+	}
+
+	if result := Uniform(generator, -2*time.Hour, -time.Hour)(minDuration); result != minDuration {
+		t.Errorf("transformation expected to return a %s duration, but received %s instead", minDuration, result)
+	}
+
+	// A range wider than the longest representable duration
+	transformation := Uniform(generator, minDuration, maxDuration)
+
+	var negative, positive bool
+
+	for i := 0; i < 100; i++ {
+		result := transformation(0)
+
+		negative = negative || result < 0
+		positive = positive || result > 0
+	}
+
+	if !negative || !positive {
+		t.Error("transformation expected to return durations across the whole range")
+	}
+}
+
+func TestToDuration(t *testing.T) {
+	cases := []struct {
+		nanoseconds float64
+		expected    time.Duration
+	}{
+		{1.5, 1},
+		{-1.5, -1},
+		{math.MaxInt64, math.MaxInt64},
+		{math.Inf(1), math.MaxInt64},
+		{math.MinInt64, math.MinInt64},
+		{math.Inf(-1), math.MinInt64},
+		{math.NaN(), 0},
+	}
+
+	for _, c := range cases {
+		if result := toDuration(c.nanoseconds); result != c.expected {
+			t.Errorf("expected %v to convert to %s, received %s instead", c.nanoseconds, c.expected, result)
+		}
+	}
+}
+
 func TestFallbackNewRandom(t *testing.T) {
 	generator := rand.New(rand.NewSource(0))
 
@@ -99,3 +380,27 @@ func TestFallbackNewRandom(t *testing.T) {
 		t.Error("received unexpected nil result")
 	}
 }
+
+func TestFallbackNewRandomConcurrentUse(t *testing.T) {
+	const goroutines = 8
+
+	transformation := Full(nil)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 1000; j++ {
+				if result := transformation(time.Second); result < 0 || result >= time.Second {
+					t.Errorf("result expected to be in [0, %s), received %s instead", time.Second, result)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
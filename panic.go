@@ -0,0 +1,51 @@
+package retry
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is returned in place of a panic that was recovered from an Action.
+type PanicError struct {
+	// Value is the value that was recovered from the panic.
+	Value any
+
+	// Stack is the stack trace of the goroutine at the time of the panic.
+	Stack []byte
+}
+
+// Error returns a description of the recovered value.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("retry: action panicked: %v", e.Value)
+}
+
+// Unwrap returns the recovered value, if it's an error.
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+
+	return nil
+}
+
+// Recover wraps the given action so that a panic in any of its attempts is
+// recovered and returned as a *PanicError, allowing for it to be retried like
+// any other error.
+func Recover(action Action) Action {
+	return func(attempt uint) (err error) {
+		// Track whether the action returned, as recover returns nil for a
+		// panic with a nil value
+		panicked := true
+
+		defer func() {
+			if panicked {
+				err = &PanicError{Value: recover(), Stack: debug.Stack()}
+			}
+		}()
+
+		err = action(attempt)
+		panicked = false
+
+		return err
+	}
+}
@@ -0,0 +1,127 @@
+package retry
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Rican7/retry/strategy"
+)
+
+func TestRecover(t *testing.T) {
+	action := Recover(func(attempt uint) error {
+		panic("nil response")
+	})
+
+	err := action(1)
+
+	var panicErr *PanicError
+
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("expected a panic error, received %q instead", err)
+	}
+
+	if panicErr.Value != "nil response" {
+		t.Errorf("expected the recovered value, received %v instead", panicErr.Value)
+	}
+
+	if !strings.Contains(string(panicErr.Stack), "TestRecover") {
+		t.Errorf("expected the stack trace to contain the panicking function, received %s instead", panicErr.Stack)
+	}
+
+	if expected := "retry: action panicked: nil response"; err.Error() != expected {
+		t.Errorf("expected the error message %q, received %q instead", expected, err)
+	}
+
+	if errors.Unwrap(err) != nil {
+		t.Error("expected a non-error value to not be unwrapped")
+	}
+}
+
+func TestRecoverError(t *testing.T) {
+	errCause := errors.New("cause")
+
+	err := Recover(func(attempt uint) error {
+		panic(errCause)
+	})(1)
+
+	if !errors.Is(err, errCause) {
+		t.Errorf("expected the error to wrap the recovered error, received %q instead", err)
+	}
+}
+
+func TestRecoverNilPanic(t *testing.T) {
+	err := Recover(func(attempt uint) error {
+		panic(nil)
+	})(1)
+
+	var panicErr *PanicError
+
+	if !errors.As(err, &panicErr) {
+		t.Errorf("expected a *PanicError, received %q instead", err)
+	}
+}
+
+func TestRecoverNoPanic(t *testing.T) {
+	errCause := errors.New("cause")
+
+	err := Recover(func(attempt uint) error {
+		return errCause
+	})(1)
+
+	if err != errCause {
+		t.Errorf("expected the action's error, received %q instead", err)
+	}
+}
+
+func TestRetryRecover(t *testing.T) {
+	const panicUntilAttemptNumber = 3
+
+	err := Retry(Recover(func(attempt uint) error {
+		if attempt < panicUntilAttemptNumber {
+			panic("flaky")
+		}
+
+		return nil
+	}))
+
+	if err != nil {
+		t.Errorf("expected a nil error, received %q instead", err)
+	}
+}
+
+func TestPolicyWithPanicRecovery(t *testing.T) {
+	const attemptLimit = 2
+
+	var attemptsMade uint
+
+	policy := NewPolicy(WithStrategies(strategy.Limit(attemptLimit))).With(WithPanicRecovery())
+
+	err := policy.Do(func(attempt uint) error {
+		attemptsMade = attempt
+
+		panic("flaky")
+	})
+
+	var panicErr *PanicError
+
+	if !errors.As(err, &panicErr) {
+		t.Errorf("expected a panic error, received %q instead", err)
+	}
+
+	if attemptLimit != attemptsMade {
+		t.Errorf("expected %d attempts to be made, but %d were made instead", attemptLimit, attemptsMade)
+	}
+}
+
+func TestPolicyWithoutPanicRecovery(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic")
+		}
+	}()
+
+	NewPolicy().Do(func(attempt uint) error {
+		panic("flaky")
+	})
+}
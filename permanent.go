@@ -0,0 +1,40 @@
+package retry
+
+import "errors"
+
+// permanentError wraps an error to mark it as permanent.
+type permanentError struct {
+	err error
+}
+
+// Permanent wraps the given error to mark it as permanent, so that an Action
+// returning it isn't retried. A nil error returns nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether the given error, or any error that it wraps, was
+// marked as permanent.
+func IsPermanent(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var permanent *permanentError
+
+	return errors.As(err, &permanent)
+}
+
+// Error returns the wrapped error's message.
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,63 @@
+package retry
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestPermanent(t *testing.T) {
+	errCause := errors.New("cause")
+
+	err := Permanent(errCause)
+
+	if !IsPermanent(err) {
+		t.Error("expected the error to be permanent")
+	}
+
+	if !IsPermanent(fmt.Errorf("wrapped: %w", err)) {
+		t.Error("expected a wrapping error to be permanent")
+	}
+
+	if !errors.Is(err, errCause) {
+		t.Error("expected the error to wrap its cause")
+	}
+
+	if err.Error() != errCause.Error() {
+		t.Errorf("expected the error message %q, received %q instead", errCause, err)
+	}
+
+	if IsPermanent(errCause) {
+		t.Error("expected the cause to not be permanent")
+	}
+}
+
+func TestPermanentNil(t *testing.T) {
+	if Permanent(nil) != nil {
+		t.Error("expected a nil error")
+	}
+}
+
+func TestRetryPermanent(t *testing.T) {
+	errCause := errors.New("cause")
+
+	var attemptsMade uint
+
+	err := Retry(func(attempt uint) error {
+		attemptsMade = attempt
+
+		if attempt == 2 {
+			return Permanent(errCause)
+		}
+
+		return errors.New("transient")
+	})
+
+	if !errors.Is(err, errCause) || !IsPermanent(err) {
+		t.Errorf("expected the permanent error, received %q instead", err)
+	}
+
+	if attemptsMade != 2 {
+		t.Errorf("expected 2 attempts to be made, but %d were made instead", attemptsMade)
+	}
+}
@@ -0,0 +1,363 @@
+package retry
+
+import (
+	"context"
+	"time"
+
+	"github.com/Rican7/retry/clock"
+	"github.com/Rican7/retry/internal/spec"
+	"github.com/Rican7/retry/strategy"
+)
+
+// Classifier defines a function that a Policy calls with every error returned
+// by an Action to determine whether the Action should be retried or not.
+// Returning `true` allows for the error to be retried. Returning `false` halts
+// the retrying process and returns the error.
+type Classifier func(err error) bool
+
+// Attempt describes a single attempt of an Action made by a Policy.
+type Attempt struct {
+	// Number is the attempt number that was passed to the Action.
+	Number uint
+
+	// Err is the error returned by the Action, if any.
+	Err error
+
+	// Start is the time that the attempt started.
+	Start time.Time
+
+	// Duration is how long the attempt took.
+	Duration time.Duration
+}
+
+// Hook defines a function that a Policy calls after every attempt.
+type Hook func(attempt Attempt)
+
+// Option defines a function that configures a Policy.
+type Option func(policy *Policy)
+
+// Policy bundles the strategies, classifiers, hooks, and clock used to retry an
+// Action, so that they may be defined once and reused.
+//
+// A Policy is immutable once created, and is therefore safe for concurrent use
+// (as long as the strategies, classifiers, and hooks that it holds are). The
+// zero value is a valid Policy that retries until successful.
+type Policy struct {
+	strategies  []strategy.Factory
+	classifiers []Classifier
+	hooks       []Hook
+	clock       clock.Clock
+	recover     bool
+	exhausted   bool
+
+	// evaluators holds the evaluator of each of the strategies that isn't
+	// created by a factory, so that it's only looked up once.
+	evaluators []evaluator
+
+	// factories is whether any of the strategies is created by a factory.
+	factories bool
+}
+
+// evaluator evaluates a Strategy of a Policy. A Strategy created by package
+// strategy is evaluated directly by its spec, so that it waits on the Policy's
+// clock and stops waiting once the context is done.
+type evaluator struct {
+	strategy strategy.Strategy
+	spec     *spec.Spec
+}
+
+// newEvaluator creates an evaluator for the given Strategy.
+func newEvaluator(s strategy.Strategy) evaluator {
+	return evaluator{strategy: s, spec: spec.Of(s)}
+}
+
+// evaluate evaluates the Strategy with the given attempt, context, and clock.
+func (e evaluator) evaluate(ctx context.Context, c clock.Clock, attempt uint) bool {
+	if e.spec == nil {
+		return e.strategy(attempt)
+	}
+
+	return e.spec.Evaluate(ctx, c, attempt)
+}
+
+// NewPolicy creates a Policy configured with the given options.
+func NewPolicy(options ...Option) *Policy {
+	return (&Policy{}).With(options...)
+}
+
+// WithStrategies creates an Option that adds the given strategies to a Policy.
+func WithStrategies(strategies ...strategy.Strategy) Option {
+	return func(policy *Policy) {
+		for _, strategy := range strategies {
+			policy.strategies = append(policy.strategies, strategy)
+		}
+	}
+}
+
+// WithFactories creates an Option that adds the given strategy factories to a
+// Policy. Each factory is used to create a new Strategy every time that the
+// Policy performs an Action.
+func WithFactories(factories ...strategy.Factory) Option {
+	return func(policy *Policy) {
+		policy.strategies = append(policy.strategies, factories...)
+	}
+}
+
+// WithClassifiers creates an Option that adds the given classifiers to a
+// Policy.
+func WithClassifiers(classifiers ...Classifier) Option {
+	return func(policy *Policy) {
+		policy.classifiers = append(policy.classifiers, classifiers...)
+	}
+}
+
+// WithHooks creates an Option that adds the given hooks to a Policy.
+func WithHooks(hooks ...Hook) Option {
+	return func(policy *Policy) {
+		policy.hooks = append(policy.hooks, hooks...)
+	}
+}
+
+// AdaptiveHook creates a Hook that records each successful attempt with the
+// given strategy.Adaptive controller. Failures are recorded by the controller's
+// Strategy itself, so the two are meant to be used together:
+//
+//	policy := retry.NewPolicy(
+//		retry.WithStrategies(controller.Strategy()),
+//		retry.WithHooks(retry.AdaptiveHook(controller)),
+//	)
+func AdaptiveHook(controller *strategy.Adaptive) Hook {
+	return func(attempt Attempt) {
+		if attempt.Err == nil {
+			controller.Success()
+		}
+	}
+}
+
+// WithClock creates an Option that sets the clock used by a Policy to time its
+// attempts, and that its strategies wait on.
+func WithClock(clock clock.Clock) Option {
+	return func(policy *Policy) {
+		policy.clock = clock
+	}
+}
+
+// WithPanicRecovery creates an Option that makes a Policy recover panics in its
+// actions, returning them as a *PanicError, as if each action were wrapped with
+// Recover.
+func WithPanicRecovery() Option {
+	return func(policy *Policy) {
+		policy.recover = true
+	}
+}
+
+// WithExhaustedError creates an Option that makes a Policy return an
+// *ExhaustedError that wraps the last error returned by an action, rather than
+// the last error itself, when a strategy halts the retrying process before the
+// action succeeds. This allows for telling exhaustion apart from other errors,
+// with `errors.Is(err, ErrAttemptsExhausted)`.
+func WithExhaustedError() Option {
+	return func(policy *Policy) {
+		policy.exhausted = true
+	}
+}
+
+// With derives a new Policy from the Policy, modified by the given options. The
+// original Policy is left unchanged.
+func (p *Policy) With(options ...Option) *Policy {
+	derived := &Policy{
+		strategies:  append([]strategy.Factory(nil), p.strategies...),
+		classifiers: append([]Classifier(nil), p.classifiers...),
+		hooks:       append([]Hook(nil), p.hooks...),
+		clock:       p.clock,
+		recover:     p.recover,
+		exhausted:   p.exhausted,
+	}
+
+	for _, option := range options {
+		option(derived)
+	}
+
+	derived.evaluators = make([]evaluator, len(derived.strategies))
+
+	for i, factory := range derived.strategies {
+		if s, ok := factory.(strategy.Strategy); ok {
+			derived.evaluators[i] = newEvaluator(s)
+		} else {
+			derived.factories = true
+		}
+	}
+
+	return derived
+}
+
+// Do takes an action and performs it, repetitively, until successful or until
+// the Policy halts the retrying process.
+//
+// An error marked as Permanent is never retried.
+func (p *Policy) Do(action Action) error {
+	return p.DoContext(context.Background(), action)
+}
+
+// DoContext takes an action and performs it, repetitively, until successful or
+// until the Policy halts the retrying process.
+//
+// An error marked as Permanent is never retried.
+//
+// The given context is checked before each attempt, and the context's error is
+// returned if it is done. Strategies that wait between attempts stop waiting as
+// soon as the context is done.
+func (p *Policy) DoContext(ctx context.Context, action Action) error {
+	return p.run(ctx, action, nil, false).Err
+}
+
+// Run takes an action and performs it, repetitively, until successful or until
+// the Policy halts the retrying process, just as DoContext does. Rather than
+// just an error, it returns a Result that describes the retrying process.
+func (p *Policy) Run(ctx context.Context, action Action) Result {
+	return p.run(ctx, action, nil, true)
+}
+
+// run performs the action as Run does, only collecting the error of every
+// attempt into the Result if told to, as the other entry points don't use them.
+// The given error is the resulting error if no attempt is made.
+func (p *Policy) run(ctx context.Context, action Action, initialErr error, collectErrors bool) Result {
+	evaluators := p.newEvaluators()
+
+	c := p.clock
+
+	if c == nil {
+		c = clock.System()
+	}
+
+	if p.recover {
+		action = Recover(action)
+	}
+
+	result := Result{Err: initialErr}
+
+	start := p.now()
+
+	for attempt := uint(0); ; attempt++ {
+		sleepStart := p.now()
+		halting := haltingEvaluator(ctx, c, attempt, evaluators)
+		result.SleepDuration += p.now().Sub(sleepStart)
+
+		if err := ctx.Err(); err != nil {
+			result.Err = err
+			result.Reason = contextStopReason(err)
+			break
+		}
+
+		if halting >= 0 {
+			result.Reason = StopLimit
+
+			if _, ok := evaluators[halting].strategy.Deadline(); ok {
+				result.Reason = StopDeadline
+			}
+
+			if p.exhausted && result.Err != nil {
+				result.Err = &ExhaustedError{
+					Err:         result.Err,
+					Attempts:    result.Attempts,
+					Strategy:    halting,
+					Description: evaluators[halting].strategy.String(),
+				}
+			}
+
+			break
+		}
+
+		attemptStart := p.now()
+		err := action(attempt + 1)
+
+		result.Attempts++
+		if collectErrors {
+			result.Errors = append(result.Errors, err)
+		}
+
+		result.Err = err
+
+		p.notify(Attempt{
+			Number:   attempt + 1,
+			Err:      err,
+			Start:    attemptStart,
+			Duration: p.now().Sub(attemptStart),
+		})
+
+		if err == nil {
+			result.Reason = StopSuccess
+			break
+		}
+
+		if IsPermanent(err) || !p.shouldRetry(err) {
+			result.Reason = StopPermanent
+			break
+		}
+	}
+
+	result.Duration = p.now().Sub(start)
+
+	return result
+}
+
+// newEvaluators creates the evaluators for a single invocation of the Policy,
+// creating a new Strategy with each of its factories.
+func (p *Policy) newEvaluators() []evaluator {
+	if !p.factories {
+		return p.evaluators
+	}
+
+	evaluators := make([]evaluator, len(p.strategies))
+
+	for i, factory := range p.strategies {
+		if _, ok := factory.(strategy.Strategy); ok {
+			evaluators[i] = p.evaluators[i]
+		} else {
+			evaluators[i] = newEvaluator(factory.New())
+		}
+	}
+
+	return evaluators
+}
+
+// haltingEvaluator evaluates the given evaluators with the given attempt,
+// context, and clock, in order, and returns the index of the first one to halt
+// the retrying process, or -1 if none do.
+func haltingEvaluator(ctx context.Context, c clock.Clock, attempt uint, evaluators []evaluator) int {
+	for i, evaluator := range evaluators {
+		if !evaluator.evaluate(ctx, c, attempt) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// now returns the current time according to the Policy's clock.
+func (p *Policy) now() time.Time {
+	if p.clock == nil {
+		return time.Now()
+	}
+
+	return p.clock.Now()
+}
+
+// notify calls each of the Policy's hooks with the given attempt.
+func (p *Policy) notify(attempt Attempt) {
+	for _, hook := range p.hooks {
+		hook(attempt)
+	}
+}
+
+// shouldRetry evaluates the Policy's classifiers with the given error to
+// determine if the error should be retried.
+func (p *Policy) shouldRetry(err error) bool {
+	for _, classifier := range p.classifiers {
+		if !classifier(err) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,309 @@
+// Package policy provides a declarative way to configure retry strategies, so
+// that retry behavior may be tuned without changing code.
+//
+// A Config may be decoded from JSON and converted into a list of strategies:
+//
+//	{
+//		"limit": 5,
+//		"backoff": {"type": "exponential", "factor": "10ms", "base": 2, "max": "5s"},
+//		"jitter": {"type": "equal"}
+//	}
+//
+// Copyright © 2026 Trevor N. Suarez (Rican7)
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Rican7/retry/backoff"
+	"github.com/Rican7/retry/jitter"
+	"github.com/Rican7/retry/strategy"
+)
+
+// Backoff algorithm types, as used by BackoffConfig.Type.
+const (
+	BackoffIncremental       = "incremental"
+	BackoffLinear            = "linear"
+	BackoffExponential       = "exponential"
+	BackoffBinaryExponential = "binary_exponential"
+	BackoffFibonacci         = "fibonacci"
+	BackoffPolynomial        = "polynomial"
+	BackoffLogarithmic       = "logarithmic"
+)
+
+// Jitter transformation types, as used by JitterConfig.Type.
+const (
+	JitterFull               = "full"
+	JitterEqual              = "equal"
+	JitterDeviation          = "deviation"
+	JitterNormalDistribution = "normal_distribution"
+)
+
+// Config defines a declarative retry policy.
+type Config struct {
+	// Limit is the maximum number of attempts to make. A zero value means that
+	// the number of attempts is unlimited.
+	Limit uint `json:"limit,omitempty"`
+
+	// Backoff configures a backoff.Algorithm to wait with before each attempt.
+	Backoff *BackoffConfig `json:"backoff,omitempty"`
+
+	// Jitter configures a jitter.Transformation to apply to the Backoff.
+	Jitter *JitterConfig `json:"jitter,omitempty"`
+
+	// Delay is a duration to wait before the first attempt.
+	Delay Duration `json:"delay,omitempty"`
+
+	// Wait is a list of durations to wait before each attempt after the first.
+	Wait []Duration `json:"wait,omitempty"`
+}
+
+// BackoffConfig defines a declarative backoff.Algorithm.
+type BackoffConfig struct {
+	// Type is the name of the algorithm, such as "exponential".
+	Type string `json:"type"`
+
+	// Initial is the initial duration of an "incremental" algorithm.
+	Initial Duration `json:"initial,omitempty"`
+
+	// Increment is the increment of an "incremental" algorithm.
+	Increment Duration `json:"increment,omitempty"`
+
+	// Factor is the factor duration of the "linear", "exponential",
+	// "binary_exponential", "fibonacci", "polynomial", and "logarithmic"
+	// algorithms.
+	Factor Duration `json:"factor,omitempty"`
+
+	// Base is the base of an "exponential" algorithm.
+	Base float64 `json:"base,omitempty"`
+
+	// Exponent is the exponent of a "polynomial" algorithm.
+	Exponent float64 `json:"exponent,omitempty"`
+
+	// Max caps the duration returned by the algorithm, if non-zero. Any jitter
+	// is capped too, so that no wait ever exceeds it.
+	Max Duration `json:"max,omitempty"`
+}
+
+// JitterConfig defines a declarative jitter.Transformation.
+type JitterConfig struct {
+	// Type is the name of the transformation, such as "equal".
+	Type string `json:"type"`
+
+	// Factor is the factor of a "deviation" transformation.
+	Factor float64 `json:"factor,omitempty"`
+
+	// StandardDeviation is the standard deviation of a "normal_distribution"
+	// transformation.
+	StandardDeviation float64 `json:"standard_deviation,omitempty"`
+}
+
+// Duration is a time.Duration that is represented in text as a duration string,
+// such as "300ms" or "1m30s".
+type Duration time.Duration
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Duration) UnmarshalText(text []byte) error {
+	duration, err := time.ParseDuration(string(text))
+
+	if err != nil {
+		return fmt.Errorf("policy: invalid duration %q", text)
+	}
+
+	*d = Duration(duration)
+
+	return nil
+}
+
+// Parse decodes and validates a JSON encoded Config.
+func Parse(data []byte) (Config, error) {
+	var config Config
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&config); err != nil {
+		return Config{}, fmt.Errorf("policy: unable to decode config: %w", err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return Config{}, err
+	}
+
+	return config, nil
+}
+
+// FromEnv decodes and validates a JSON encoded Config from the environment
+// variable named by the given key.
+func FromEnv(key string) (Config, error) {
+	value, ok := os.LookupEnv(key)
+
+	if !ok {
+		return Config{}, fmt.Errorf("policy: environment variable %q is not set", key)
+	}
+
+	return Parse([]byte(value))
+}
+
+// Validate checks the Config for invalid values.
+func (c Config) Validate() error {
+	if c.Delay < 0 {
+		return errors.New("policy: delay must not be negative")
+	}
+
+	for _, wait := range c.Wait {
+		if wait < 0 {
+			return errors.New("policy: wait durations must not be negative")
+		}
+	}
+
+	if c.Jitter != nil && c.Backoff == nil {
+		return errors.New("policy: jitter requires a backoff")
+	}
+
+	if c.Backoff != nil {
+		if _, err := c.Backoff.Algorithm(); err != nil {
+			return err
+		}
+	}
+
+	if c.Jitter != nil {
+		if _, err := c.Jitter.Transformation(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Strategies validates the Config and converts it into a list of strategies.
+func (c Config) Strategies() ([]strategy.Strategy, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	var strategies []strategy.Strategy
+
+	if c.Limit > 0 {
+		strategies = append(strategies, strategy.Limit(c.Limit))
+	}
+
+	if c.Delay > 0 {
+		strategies = append(strategies, strategy.Delay(time.Duration(c.Delay)))
+	}
+
+	if len(c.Wait) > 0 {
+		durations := make([]time.Duration, len(c.Wait))
+
+		for i, wait := range c.Wait {
+			durations[i] = time.Duration(wait)
+		}
+
+		strategies = append(strategies, strategy.Wait(durations...))
+	}
+
+	if c.Backoff != nil {
+		// Errors were already checked during validation
+		algorithm, _ := c.Backoff.Algorithm()
+
+		if c.Jitter != nil {
+			transformation, _ := c.Jitter.Transformation()
+
+			if c.Backoff.Max > 0 {
+				transformation = jitter.Clamp(transformation, 0, time.Duration(c.Backoff.Max))
+			}
+
+			strategies = append(strategies, strategy.BackoffWithJitter(algorithm, transformation))
+		} else {
+			strategies = append(strategies, strategy.Backoff(algorithm))
+		}
+	}
+
+	return strategies, nil
+}
+
+// Algorithm validates the BackoffConfig and converts it into a
+// backoff.Algorithm.
+func (c BackoffConfig) Algorithm() (backoff.Algorithm, error) {
+	if c.Initial < 0 || c.Increment < 0 || c.Factor < 0 || c.Max < 0 {
+		return nil, fmt.Errorf("policy: %q backoff durations must not be negative", c.Type)
+	}
+
+	var algorithm backoff.Algorithm
+
+	switch c.Type {
+	case BackoffIncremental:
+		if c.Initial == 0 && c.Increment == 0 {
+			return nil, fmt.Errorf("policy: %q backoff requires a positive initial duration or increment", c.Type)
+		}
+
+		algorithm = backoff.Incremental(time.Duration(c.Initial), time.Duration(c.Increment))
+	case BackoffLinear:
+		algorithm = backoff.Linear(time.Duration(c.Factor))
+	case BackoffExponential:
+		if c.Base <= 0 {
+			return nil, fmt.Errorf("policy: %q backoff requires a positive base", c.Type)
+		}
+
+		algorithm = backoff.Exponential(time.Duration(c.Factor), c.Base)
+	case BackoffBinaryExponential:
+		algorithm = backoff.BinaryExponential(time.Duration(c.Factor))
+	case BackoffFibonacci:
+		algorithm = backoff.Fibonacci(time.Duration(c.Factor))
+	case BackoffPolynomial:
+		if c.Exponent <= 0 {
+			return nil, fmt.Errorf("policy: %q backoff requires a positive exponent", c.Type)
+		}
+
+		algorithm = backoff.Polynomial(time.Duration(c.Factor), c.Exponent)
+	case BackoffLogarithmic:
+		algorithm = backoff.Logarithmic(time.Duration(c.Factor))
+	default:
+		return nil, fmt.Errorf("policy: unknown backoff type %q", c.Type)
+	}
+
+	if c.Type != BackoffIncremental && c.Factor == 0 {
+		return nil, fmt.Errorf("policy: %q backoff requires a positive factor", c.Type)
+	}
+
+	if c.Max > 0 {
+		algorithm = backoff.Min(algorithm, backoff.Incremental(time.Duration(c.Max), 0))
+	}
+
+	return algorithm, nil
+}
+
+// Transformation validates the JitterConfig and converts it into a
+// jitter.Transformation.
+func (c JitterConfig) Transformation() (jitter.Transformation, error) {
+	switch c.Type {
+	case JitterFull:
+		return jitter.Full(nil), nil
+	case JitterEqual:
+		return jitter.Equal(nil), nil
+	case JitterDeviation:
+		if c.Factor <= 0 || c.Factor > 1 {
+			return nil, fmt.Errorf("policy: %q jitter requires a factor in (0, 1]", c.Type)
+		}
+
+		return jitter.Deviation(nil, c.Factor), nil
+	case JitterNormalDistribution:
+		if c.StandardDeviation <= 0 {
+			return nil, fmt.Errorf("policy: %q jitter requires a positive standard deviation", c.Type)
+		}
+
+		return jitter.NormalDistribution(nil, c.StandardDeviation), nil
+	}
+
+	return nil, fmt.Errorf("policy: unknown jitter type %q", c.Type)
+}
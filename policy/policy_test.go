@@ -0,0 +1,238 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Rican7/retry/retrytest"
+)
+
+func TestParse(t *testing.T) {
+	const data = `{"limit":5,"backoff":{"type":"exponential","factor":"10ms","base":2,"max":"5s"},"jitter":{"type":"equal"}}`
+
+	config, err := Parse([]byte(data))
+
+	if err != nil {
+		t.Fatalf("expected a nil error, received %q instead", err)
+	}
+
+	if config.Limit != 5 {
+		t.Errorf("expected a limit of 5, received %d instead", config.Limit)
+	}
+
+	if config.Backoff == nil || config.Backoff.Type != BackoffExponential {
+		t.Fatalf("expected an %q backoff, received %+v instead", BackoffExponential, config.Backoff)
+	}
+
+	if time.Duration(config.Backoff.Factor) != 10*time.Millisecond {
+		t.Errorf("expected a factor of 10ms, received %s instead", time.Duration(config.Backoff.Factor))
+	}
+
+	if time.Duration(config.Backoff.Max) != 5*time.Second {
+		t.Errorf("expected a max of 5s, received %s instead", time.Duration(config.Backoff.Max))
+	}
+
+	if config.Jitter == nil || config.Jitter.Type != JitterEqual {
+		t.Errorf("expected an %q jitter, received %+v instead", JitterEqual, config.Jitter)
+	}
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	inputs := []string{
+		`{"limit":5,"backoff":{"type":"exponential","factor":"10ms","base":2,"max":"5s"},"jitter":{"type":"equal"}}`,
+		`{"backoff":{"type":"incremental","initial":"1s","increment":"1m30s"},"jitter":{"type":"deviation","factor":0.5}}`,
+		`{"limit":3,"delay":"100ms","wait":["1s","2s"]}`,
+		`{"backoff":{"type":"polynomial","factor":"10ms","exponent":2}}`,
+		`{}`,
+	}
+
+	for _, input := range inputs {
+		config, err := Parse([]byte(input))
+
+		if err != nil {
+			t.Fatalf("expected a nil error for %s, received %q instead", input, err)
+		}
+
+		output, err := json.Marshal(config)
+
+		if err != nil {
+			t.Fatalf("expected a nil error, received %q instead", err)
+		}
+
+		if string(output) != input {
+			t.Errorf("expected %s to round-trip, received %s instead", input, output)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	inputs := map[string]string{
+		`{"limit":-1}`:                                     "unable to decode",
+		`{"retries":5}`:                                    "unknown field",
+		`{"delay":"soon"}`:                                 `invalid duration "soon"`,
+		`{"delay":"-1s"}`:                                  "delay must not be negative",
+		`{"wait":["1s","-1s"]}`:                            "wait durations must not be negative",
+		`{"backoff":{"type":"quadratic"}}`:                 `unknown backoff type "quadratic"`,
+		`{"backoff":{"type":"incremental"}}`:               "requires a positive initial duration or increment",
+		`{"backoff":{"type":"linear"}}`:                    "requires a positive factor",
+		`{"backoff":{"type":"linear","factor":"-1s"}}`:     "must not be negative",
+		`{"backoff":{"type":"exponential","factor":"1s"}}`: "requires a positive base",
+		`{"backoff":{"type":"polynomial","factor":"1s"}}`:  "requires a positive exponent",
+		`{"jitter":{"type":"full"}}`:                       "jitter requires a backoff",
+		`{"limit":3,"backoff":{"type":"incremental"},"jitter":{"type":"full"}}`:               "requires a positive initial duration or increment",
+		`{"backoff":{"type":"linear","factor":"1s"},"jitter":{"type":"wobbly"}}`:              `unknown jitter type "wobbly"`,
+		`{"backoff":{"type":"linear","factor":"1s"},"jitter":{"type":"deviation"}}`:           "requires a factor",
+		`{"backoff":{"type":"linear","factor":"1s"},"jitter":{"type":"normal_distribution"}}`: "requires a positive standard deviation",
+	}
+
+	for input, expected := range inputs {
+		_, err := Parse([]byte(input))
+
+		if err == nil {
+			t.Errorf("expected an error for %s", input)
+			continue
+		}
+
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error for %s to contain %q, received %q instead", input, expected, err)
+		}
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	const key = "RETRY_POLICY_TEST"
+
+	t.Setenv(key, `{"limit":2}`)
+
+	config, err := FromEnv(key)
+
+	if err != nil {
+		t.Fatalf("expected a nil error, received %q instead", err)
+	}
+
+	if config.Limit != 2 {
+		t.Errorf("expected a limit of 2, received %d instead", config.Limit)
+	}
+
+	if _, err := FromEnv(key + "_UNSET"); err == nil {
+		t.Error("expected an error for an unset variable")
+	}
+}
+
+func TestStrategies(t *testing.T) {
+	config := Config{
+		Limit:   3,
+		Delay:   Duration(time.Nanosecond),
+		Wait:    []Duration{Duration(time.Nanosecond)},
+		Backoff: &BackoffConfig{Type: BackoffLinear, Factor: Duration(time.Nanosecond)},
+		Jitter:  &JitterConfig{Type: JitterFull},
+	}
+
+	strategies, err := config.Strategies()
+
+	if err != nil {
+		t.Fatalf("expected a nil error, received %q instead", err)
+	}
+
+	if len(strategies) != 4 {
+		t.Fatalf("expected 4 strategies, received %d instead", len(strategies))
+	}
+
+	for attempt := uint(0); attempt < config.Limit; attempt++ {
+		for _, strategy := range strategies {
+			if !strategy(attempt) {
+				t.Errorf("strategy expected to return true for attempt %d", attempt)
+			}
+		}
+	}
+
+	if strategies[0](config.Limit) {
+		t.Error("limit strategy expected to return false")
+	}
+}
+
+func TestStrategiesJitterMax(t *testing.T) {
+	const max = time.Second
+
+	config := Config{
+		Backoff: &BackoffConfig{Type: BackoffLinear, Factor: Duration(max), Max: Duration(max)},
+		Jitter:  &JitterConfig{Type: JitterNormalDistribution, StandardDeviation: float64(time.Hour)},
+	}
+
+	strategies, err := config.Strategies()
+
+	if err != nil {
+		t.Fatalf("expected a nil error, received %q instead", err)
+	}
+
+	clock := retrytest.NewClock(time.Now())
+	strategy := strategies[0].Bind(context.Background(), clock)
+
+	for attempt := uint(1); attempt <= 100; attempt++ {
+		before := clock.Now()
+
+		strategy(attempt)
+
+		if waited := clock.Now().Sub(before); waited < 0 || waited > max {
+			t.Errorf("expected to wait at most %s, but waited %s instead", max, waited)
+		}
+	}
+}
+
+func TestStrategiesInvalid(t *testing.T) {
+	config := Config{Backoff: &BackoffConfig{Type: "unknown"}}
+
+	if _, err := config.Strategies(); err == nil {
+		t.Error("expected an error for an invalid config")
+	}
+}
+
+func TestAlgorithmMax(t *testing.T) {
+	config := BackoffConfig{
+		Type:   BackoffExponential,
+		Factor: Duration(time.Second),
+		Base:   2,
+		Max:    Duration(5 * time.Second),
+	}
+
+	algorithm, err := config.Algorithm()
+
+	if err != nil {
+		t.Fatalf("expected a nil error, received %q instead", err)
+	}
+
+	expectedDurations := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+
+	for i, expected := range expectedDurations {
+		if result := algorithm(uint(i)); result != expected {
+			t.Errorf("algorithm expected to return a %s duration, but received %s instead", expected, result)
+		}
+	}
+}
+
+func TestAlgorithmTypes(t *testing.T) {
+	configs := map[string]BackoffConfig{
+		BackoffPolynomial:  {Type: BackoffPolynomial, Factor: Duration(time.Second), Exponent: 2},
+		BackoffLogarithmic: {Type: BackoffLogarithmic, Factor: Duration(time.Second)},
+	}
+
+	expectedDurations := map[string]time.Duration{
+		BackoffPolynomial:  9 * time.Second,
+		BackoffLogarithmic: 2 * time.Second,
+	}
+
+	for name, config := range configs {
+		algorithm, err := config.Algorithm()
+
+		if err != nil {
+			t.Fatalf("expected a nil error for %s, received %q instead", name, err)
+		}
+
+		if result := algorithm(3); result != expectedDurations[name] {
+			t.Errorf("%s algorithm expected to return a %s duration, but received %s instead", name, expectedDurations[name], result)
+		}
+	}
+}
@@ -0,0 +1,429 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Rican7/retry"
+	"github.com/Rican7/retry/backoff"
+	"github.com/Rican7/retry/jitter"
+	"github.com/Rican7/retry/retrytest"
+	"github.com/Rican7/retry/strategy"
+)
+
+func TestPolicyZeroValue(t *testing.T) {
+	const errorUntilAttemptNumber = 3
+
+	var policy retry.Policy
+
+	err := policy.Do(func(attempt uint) error {
+		if errorUntilAttemptNumber == attempt {
+			return nil
+		}
+
+		return errors.New("erroring")
+	})
+
+	if err != nil {
+		t.Error("expected a nil error")
+	}
+}
+
+func TestPolicyStrategies(t *testing.T) {
+	const attemptLimit = 3
+
+	var attemptsMade uint
+
+	policy := retry.NewPolicy(retry.WithStrategies(strategy.Limit(attemptLimit)))
+
+	err := policy.Do(func(attempt uint) error {
+		attemptsMade = attempt
+
+		return errors.New("erroring")
+	})
+
+	if err == nil {
+		t.Error("expected a non-nil error")
+	}
+
+	if attemptLimit != attemptsMade {
+		t.Errorf(
+			"expected %d attempts to be made, but %d were made instead",
+			attemptLimit,
+			attemptsMade,
+		)
+	}
+}
+
+func TestPolicyClassifiers(t *testing.T) {
+	permanentErr := errors.New("permanent")
+
+	var attemptsMade uint
+
+	policy := retry.NewPolicy(retry.WithClassifiers(
+		func(err error) bool {
+			return true
+		},
+		func(err error) bool {
+			return !errors.Is(err, permanentErr)
+		},
+	))
+
+	err := policy.Do(func(attempt uint) error {
+		attemptsMade = attempt
+
+		if attempt == 2 {
+			return permanentErr
+		}
+
+		return errors.New("transient")
+	})
+
+	if err != permanentErr {
+		t.Errorf("expected the permanent error, received %q instead", err)
+	}
+
+	if attemptsMade != 2 {
+		t.Errorf("expected 2 attempts to be made, but %d were made instead", attemptsMade)
+	}
+}
+
+func TestPolicyHooks(t *testing.T) {
+	const step = time.Second
+
+	transientErr := errors.New("transient")
+	clock := retrytest.NewClock(time.Now())
+
+	var attempts []retry.Attempt
+
+	policy := retry.NewPolicy(
+		retry.WithClock(clock),
+		retry.WithHooks(func(attempt retry.Attempt) {
+			attempts = append(attempts, attempt)
+		}),
+	)
+
+	err := policy.Do(func(attempt uint) error {
+		clock.Advance(step)
+
+		if attempt < 3 {
+			return transientErr
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		t.Error("expected a nil error")
+	}
+
+	if len(attempts) != 3 {
+		t.Fatalf("expected 3 attempts to be recorded, but %d were instead", len(attempts))
+	}
+
+	for i, attempt := range attempts {
+		if attempt.Number != uint(i+1) {
+			t.Errorf("expected attempt number %d, received %d instead", i+1, attempt.Number)
+		}
+
+		if attempt.Duration != step {
+			t.Errorf("expected attempt duration %s, received %s instead", step, attempt.Duration)
+		}
+	}
+
+	if attempts[0].Err != transientErr || attempts[2].Err != nil {
+		t.Error("expected attempts to record the errors returned")
+	}
+}
+
+func TestPolicyDoContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var attemptsMade uint
+
+	err := retry.NewPolicy().DoContext(ctx, func(attempt uint) error {
+		attemptsMade = attempt
+
+		if attempt == 2 {
+			cancel()
+		}
+
+		return errors.New("erroring")
+	})
+
+	if err != context.Canceled {
+		t.Errorf("expected a context canceled error, received %q instead", err)
+	}
+
+	if attemptsMade != 2 {
+		t.Errorf("expected 2 attempts to be made, but %d were made instead", attemptsMade)
+	}
+}
+
+func TestPolicyWith(t *testing.T) {
+	var hookCalls int
+
+	hook := func(attempt retry.Attempt) {
+		hookCalls++
+	}
+
+	base := retry.NewPolicy(retry.WithStrategies(strategy.Limit(5)), retry.WithHooks(hook))
+	derived := base.With(retry.WithStrategies(strategy.Limit(1)), retry.WithHooks(hook))
+
+	action := func(attempt uint) error {
+		return errors.New("erroring")
+	}
+
+	derived.Do(action)
+
+	if hookCalls != 2 {
+		t.Errorf("expected derived policy to call 2 hooks once, but %d calls were made", hookCalls)
+	}
+
+	hookCalls = 0
+
+	base.Do(action)
+
+	if hookCalls != 5 {
+		t.Errorf("expected base policy to be unchanged, but %d hook calls were made", hookCalls)
+	}
+}
+
+func TestPolicyConcurrentUse(t *testing.T) {
+	const goroutines = 10
+	const attemptLimit = 3
+
+	policy := retry.NewPolicy(retry.WithStrategies(strategy.Limit(attemptLimit)))
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			var attemptsMade uint
+
+			policy.Do(func(attempt uint) error {
+				attemptsMade = attempt
+
+				return errors.New("erroring")
+			})
+
+			if attemptsMade != attemptLimit {
+				t.Errorf("expected %d attempts to be made, but %d were made instead", attemptLimit, attemptsMade)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestPolicyFactories(t *testing.T) {
+	const budget = 3
+
+	var factoryCalls int
+
+	policy := retry.NewPolicy(retry.WithFactories(strategy.FactoryFunc(func() strategy.Strategy {
+		factoryCalls++
+
+		remaining := budget
+
+		return func(attempt uint) bool {
+			remaining--
+
+			return remaining >= 0
+		}
+	})))
+
+	for i := 1; i <= 2; i++ {
+		var attemptsMade uint
+
+		policy.Do(func(attempt uint) error {
+			attemptsMade = attempt
+
+			return errors.New("erroring")
+		})
+
+		if attemptsMade != budget {
+			t.Errorf("expected %d attempts to be made, but %d were made instead", budget, attemptsMade)
+		}
+
+		if factoryCalls != i {
+			t.Errorf("expected the factory to be called %d times, but it was called %d times", i, factoryCalls)
+		}
+	}
+}
+
+func TestPolicyClockWaits(t *testing.T) {
+	policy := retry.NewPolicy(
+		retry.WithStrategies(strategy.Limit(3), strategy.Wait(time.Hour)),
+		retry.WithClock(retrytest.NewClock(time.Now())),
+	)
+
+	result := policy.Run(context.Background(), func(attempt uint) error {
+		return errors.New("erroring")
+	})
+
+	if result.Attempts != 3 {
+		t.Errorf("expected 3 attempts to be made, but %d were made instead", result.Attempts)
+	}
+
+	if expected := 2 * time.Hour; result.SleepDuration != expected {
+		t.Errorf("expected a sleep duration of %s, received %s instead", expected, result.SleepDuration)
+	}
+}
+
+func TestPolicyRun(t *testing.T) {
+	const sleep = time.Second
+
+	clock := retrytest.NewClock(time.Now())
+	errFailed := errors.New("failed")
+
+	policy := retry.NewPolicy(
+		retry.WithClock(clock),
+		retry.WithStrategies(func(attempt uint) bool {
+			if attempt > 0 {
+				<-clock.After(sleep)
+			}
+
+			return true
+		}),
+	)
+
+	result := policy.Run(context.Background(), func(attempt uint) error {
+		if attempt < 3 {
+			return errFailed
+		}
+
+		return nil
+	})
+
+	if result.Err != nil {
+		t.Errorf("expected a nil error, received %q instead", result.Err)
+	}
+
+	if result.Reason != retry.StopSuccess {
+		t.Errorf("expected the %q reason, received %q instead", retry.StopSuccess, result.Reason)
+	}
+
+	if result.Attempts != 3 {
+		t.Errorf("expected 3 attempts, received %d instead", result.Attempts)
+	}
+
+	if len(result.Errors) != 3 || result.Errors[0] != errFailed || result.Errors[1] != errFailed || result.Errors[2] != nil {
+		t.Errorf("expected the errors of each attempt, received %v instead", result.Errors)
+	}
+
+	if result.SleepDuration != 2*sleep {
+		t.Errorf("expected a sleep duration of %s, received %s instead", 2*sleep, result.SleepDuration)
+	}
+
+	if result.Duration != 2*sleep {
+		t.Errorf("expected a duration of %s, received %s instead", 2*sleep, result.Duration)
+	}
+}
+
+func TestPolicyRunStrategyDeadline(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	clock := retrytest.NewClock(time.Now())
+
+	policy := retry.NewPolicy(
+		retry.WithStrategies(strategy.Deadline(clock.Now().Add(3*time.Second))),
+		retry.WithClock(clock),
+	)
+
+	result := policy.Run(context.Background(), func(attempt uint) error {
+		clock.After(2 * time.Second)
+
+		return errFailed
+	})
+
+	if result.Reason != retry.StopDeadline {
+		t.Errorf("expected the %q reason, received %q instead", retry.StopDeadline, result.Reason)
+	}
+
+	if !errors.Is(result.Err, errFailed) {
+		t.Errorf("expected the %q error, received %q instead", errFailed, result.Err)
+	}
+
+	if result.Attempts != 2 {
+		t.Errorf("expected 2 attempts, received %d instead", result.Attempts)
+	}
+}
+
+func TestPolicyDoContextInterruptsWait(t *testing.T) {
+	const cancelAfter = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	policy := retry.NewPolicy(retry.WithStrategies(strategy.Wait(time.Hour)))
+
+	time.AfterFunc(cancelAfter, cancel)
+
+	start := time.Now()
+
+	err := policy.DoContext(ctx, func(attempt uint) error {
+		return errors.New("erroring")
+	})
+
+	if err != context.Canceled {
+		t.Errorf("expected a context canceled error, received %q instead", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the wait to be interrupted after %s, but it took %s instead", cancelAfter, elapsed)
+	}
+}
+
+func TestPolicyConcurrentUseWithJitter(t *testing.T) {
+	const goroutines = 8
+	const attemptLimit = 3
+
+	policy := retry.NewPolicy(retry.WithStrategies(
+		strategy.Limit(attemptLimit),
+		strategy.BackoffWithJitter(backoff.Linear(time.Microsecond), jitter.Full(nil)),
+	))
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			result := policy.Run(context.Background(), func(attempt uint) error {
+				return errors.New("erroring")
+			})
+
+			if result.Attempts != attemptLimit {
+				t.Errorf("expected %d attempts to be made, but %d were made instead", attemptLimit, result.Attempts)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func BenchmarkPolicyDo(b *testing.B) {
+	action := func(attempt uint) error {
+		return nil
+	}
+
+	policy := retry.NewPolicy(retry.WithStrategies(strategy.Limit(3), strategy.Wait(0)))
+
+	b.ReportAllocs()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = policy.Do(action)
+		}
+	})
+}
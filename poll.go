@@ -0,0 +1,55 @@
+package retry
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Rican7/retry/strategy"
+)
+
+// ErrNotDone is wrapped by the error returned by Poll when a strategy halts the
+// polling process before the condition is done.
+var ErrNotDone = errors.New("retry: condition not done")
+
+// Condition defines a function that Poll calls to check whether a condition is
+// done. Returning a non-nil error halts the polling process.
+type Condition func() (done bool, err error)
+
+// Poll takes a condition and checks it, repetitively, until it's done.
+//
+// Unlike with Retry, a condition that isn't done yet isn't an error: errors
+// returned by the condition are never retried, and are returned as is.
+//
+// Optionally, strategies may be passed that assess whether or not the condition
+// should be checked, such as `strategy.Wait` or `strategy.Backoff` to wait
+// between checks, and `strategy.Deadline` to give up after a while. If a
+// strategy halts the polling process before the condition is done, an
+// *ExhaustedError that wraps ErrNotDone is returned.
+//
+// The given context is checked before each check of the condition, and the
+// context's error is returned if it is done.
+func Poll(ctx context.Context, condition Condition, strategies ...strategy.Strategy) error {
+	var conditionErr error
+
+	// The condition isn't done until it's checked, even if it never is
+	result := NewPolicy(WithStrategies(strategies...), WithExhaustedError()).run(ctx, func(attempt uint) error {
+		done, err := condition()
+
+		switch {
+		case err != nil:
+			conditionErr = err
+
+			return Permanent(err)
+		case !done:
+			return ErrNotDone
+		}
+
+		return nil
+	}, ErrNotDone, false)
+
+	if conditionErr != nil {
+		return conditionErr
+	}
+
+	return result.Err
+}
@@ -0,0 +1,142 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Rican7/retry/backoff"
+	"github.com/Rican7/retry/strategy"
+)
+
+func TestPoll(t *testing.T) {
+	const doneAfterChecks = 3
+
+	var checks int
+
+	err := Poll(context.Background(), func() (bool, error) {
+		checks++
+
+		return checks == doneAfterChecks, nil
+	}, strategy.Wait(time.Millisecond))
+
+	if err != nil {
+		t.Errorf("expected a nil error, received %q instead", err)
+	}
+
+	if checks != doneAfterChecks {
+		t.Errorf("expected %d checks to be made, but %d were made instead", doneAfterChecks, checks)
+	}
+}
+
+func TestPollConditionError(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	var checks int
+
+	err := Poll(context.Background(), func() (bool, error) {
+		checks++
+
+		return false, errFailed
+	}, strategy.Limit(5))
+
+	if err != errFailed {
+		t.Errorf("expected the condition's error, received %q instead", err)
+	}
+
+	if checks != 1 {
+		t.Errorf("expected 1 check to be made, but %d were made instead", checks)
+	}
+}
+
+func TestPollExhausted(t *testing.T) {
+	const attemptLimit = 3
+
+	var checks int
+
+	err := Poll(context.Background(), func() (bool, error) {
+		checks++
+
+		return false, nil
+	}, strategy.Limit(attemptLimit))
+
+	if !errors.Is(err, ErrNotDone) || !errors.Is(err, ErrAttemptsExhausted) {
+		t.Errorf("expected an exhausted error wrapping ErrNotDone, received %q instead", err)
+	}
+
+	if checks != attemptLimit {
+		t.Errorf("expected %d checks to be made, but %d were made instead", attemptLimit, checks)
+	}
+}
+
+func TestPollDeadline(t *testing.T) {
+	const deadlineDuration = 20 * time.Millisecond
+
+	start := time.Now()
+
+	err := Poll(context.Background(), func() (bool, error) {
+		return false, nil
+	}, strategy.Backoff(backoff.Linear(time.Millisecond)), strategy.Deadline(start.Add(deadlineDuration)))
+
+	if !errors.Is(err, ErrNotDone) {
+		t.Errorf("expected an error wrapping ErrNotDone, received %q instead", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < deadlineDuration {
+		t.Errorf("expected polling to last at least %s, but it lasted %s", deadlineDuration, elapsed)
+	}
+}
+
+func TestPollContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var checks int
+
+	err := Poll(ctx, func() (bool, error) {
+		checks++
+
+		if checks == 2 {
+			cancel()
+		}
+
+		return false, nil
+	})
+
+	if err != context.Canceled {
+		t.Errorf("expected a context canceled error, received %q instead", err)
+	}
+
+	if checks != 2 {
+		t.Errorf("expected 2 checks to be made, but %d were made instead", checks)
+	}
+}
+
+func TestPollNoChecks(t *testing.T) {
+	strategies := map[string]strategy.Strategy{
+		"limit":    strategy.Limit(0),
+		"deadline": strategy.Deadline(time.Now().Add(-time.Minute)),
+	}
+
+	for name, halting := range strategies {
+		var checks int
+
+		err := Poll(context.Background(), func() (bool, error) {
+			checks++
+
+			return true, nil
+		}, halting)
+
+		var exhaustedErr *ExhaustedError
+
+		if !errors.As(err, &exhaustedErr) || !errors.Is(err, ErrNotDone) {
+			t.Errorf("%s: expected an exhausted error wrapping ErrNotDone, received %q instead", name, err)
+		} else if exhaustedErr.Attempts != 0 {
+			t.Errorf("%s: expected no attempts, received %d instead", name, exhaustedErr.Attempts)
+		}
+
+		if checks != 0 {
+			t.Errorf("%s: expected no checks to be made, but %d were made instead", name, checks)
+		}
+	}
+}
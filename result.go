@@ -0,0 +1,80 @@
+package retry
+
+import (
+	"context"
+	"time"
+)
+
+// StopReason describes why a retrying process stopped.
+type StopReason int
+
+// Reasons that a retrying process may stop.
+const (
+	// StopSuccess means that the action succeeded.
+	StopSuccess StopReason = iota
+
+	// StopLimit means that a strategy halted the retrying process, such as when
+	// a strategy.Limit is reached.
+	StopLimit
+
+	// StopPermanent means that the action returned an error that was marked as
+	// Permanent or that a Classifier determined shouldn't be retried.
+	StopPermanent
+
+	// StopDeadline means that the context's deadline was exceeded, or that a
+	// strategy.Deadline halted the retrying process.
+	StopDeadline
+
+	// StopCanceled means that the context was canceled.
+	StopCanceled
+)
+
+// String returns a name for the StopReason.
+func (r StopReason) String() string {
+	switch r {
+	case StopSuccess:
+		return "success"
+	case StopLimit:
+		return "limit"
+	case StopPermanent:
+		return "permanent"
+	case StopDeadline:
+		return "deadline"
+	case StopCanceled:
+		return "canceled"
+	}
+
+	return "unknown"
+}
+
+// Result describes a completed retrying process.
+type Result struct {
+	// Err is the resulting error of the retrying process.
+	Err error
+
+	// Reason is the reason that the retrying process stopped.
+	Reason StopReason
+
+	// Attempts is the number of attempts that were made.
+	Attempts uint
+
+	// Errors are the errors returned by each attempt, in order. The error of a
+	// successful attempt is nil. Errors are only collected by Policy.Run.
+	Errors []error
+
+	// Duration is how long the retrying process took in total.
+	Duration time.Duration
+
+	// SleepDuration is how much of the Duration was spent evaluating strategies,
+	// which is where strategies sleep between attempts.
+	SleepDuration time.Duration
+}
+
+// contextStopReason returns the StopReason for the given context error.
+func contextStopReason(err error) StopReason {
+	if err == context.DeadlineExceeded {
+		return StopDeadline
+	}
+
+	return StopCanceled
+}
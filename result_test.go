@@ -0,0 +1,115 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Rican7/retry/strategy"
+)
+
+func TestStopReasonString(t *testing.T) {
+	reasons := map[StopReason]string{
+		StopSuccess:    "success",
+		StopLimit:      "limit",
+		StopPermanent:  "permanent",
+		StopDeadline:   "deadline",
+		StopCanceled:   "canceled",
+		StopReason(99): "unknown",
+	}
+
+	for reason, expected := range reasons {
+		if reason.String() != expected {
+			t.Errorf("expected %q, received %q instead", expected, reason.String())
+		}
+	}
+}
+
+func TestPolicyRunReasons(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	failing := func(attempt uint) error {
+		return errFailed
+	}
+
+	expired, cancel := context.WithDeadline(context.Background(), time.Time{})
+	defer cancel()
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := map[StopReason]struct {
+		ctx    context.Context
+		policy *Policy
+		action Action
+		err    error
+	}{
+		StopLimit: {
+			ctx:    context.Background(),
+			policy: NewPolicy(WithStrategies(strategy.Limit(2))),
+			action: failing,
+			err:    errFailed,
+		},
+		StopPermanent: {
+			ctx: context.Background(),
+			policy: NewPolicy(WithClassifiers(func(err error) bool {
+				return false
+			})),
+			action: failing,
+			err:    errFailed,
+		},
+		StopDeadline: {
+			ctx:    expired,
+			policy: NewPolicy(),
+			action: failing,
+			err:    context.DeadlineExceeded,
+		},
+		StopCanceled: {
+			ctx:    canceled,
+			policy: NewPolicy(),
+			action: failing,
+			err:    context.Canceled,
+		},
+	}
+
+	for expected, test := range tests {
+		result := test.policy.Run(test.ctx, test.action)
+
+		if result.Reason != expected {
+			t.Errorf("expected the %q reason, received %q instead", expected, result.Reason)
+		}
+
+		if result.Err != test.err {
+			t.Errorf("expected the %q error for the %q reason, received %q instead", test.err, expected, result.Err)
+		}
+	}
+}
+
+func TestPolicyDoDoesNotCollectErrors(t *testing.T) {
+	policy := NewPolicy(WithStrategies(strategy.Limit(3)))
+
+	result := policy.run(context.Background(), func(attempt uint) error {
+		return errors.New("failed")
+	}, nil, false)
+
+	if result.Attempts != 3 {
+		t.Errorf("expected 3 attempts, received %d instead", result.Attempts)
+	}
+
+	if result.Errors != nil {
+		t.Errorf("expected no errors to be collected, received %v instead", result.Errors)
+	}
+}
+
+func TestPolicyRunNoAttempts(t *testing.T) {
+	result := NewPolicy(WithStrategies(strategy.Limit(0))).Run(context.Background(), func(attempt uint) error {
+		t.Error("expected the action to not be called")
+
+		return nil
+	})
+
+	if result.Attempts != 0 || result.Err != nil || result.Reason != StopLimit {
+		t.Errorf("expected no attempts to be made, received %+v instead", result)
+	}
+}
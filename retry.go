@@ -4,33 +4,69 @@
 // Copyright © 2016 Trevor N. Suarez (Rican7)
 package retry
 
-import "github.com/Rican7/retry/strategy"
+import (
+	"context"
+
+	"github.com/Rican7/retry/strategy"
+)
 
 // Action defines a callable function that package retry can handle.
 type Action func(attempt uint) error
 
+// ContextAction defines a callable function that package retry can handle,
+// which is passed a context that is cancelled once its result is no longer
+// needed.
+type ContextAction func(ctx context.Context, attempt uint) error
+
 // Retry takes an action and performs it, repetitively, until successful.
 //
 // Optionally, strategies may be passed that assess whether or not an attempt
 // should be made.
+//
+// Retry is equivalent to calling Do on a Policy created with the given
+// strategies. Strategies that hold state between attempts should instead be
+// used as a strategy.Factory, with RetryWithFactories.
 func Retry(action Action, strategies ...strategy.Strategy) error {
 	var err error
 
 	for attempt := uint(0); (attempt == 0 || err != nil) && shouldAttempt(attempt, strategies...); attempt++ {
 		err = action(attempt + 1)
+
+		if IsPermanent(err) {
+			break
+		}
 	}
 
 	return err
 }
 
+// RetryWithFactories takes an action and performs it, repetitively, until
+// successful, just as Retry does. Rather than strategies, it's passed strategy
+// factories, each of which is used to create a new Strategy for the retrying
+// process, so that strategies that hold state between attempts may be defined
+// once and reused.
+//
+// RetryWithFactories is equivalent to calling Do on a Policy created with the
+// given factories.
+func RetryWithFactories(action Action, factories ...strategy.Factory) error {
+	return NewPolicy(WithFactories(factories...)).Do(action)
+}
+
 // shouldAttempt evaluates the provided strategies with the given attempt to
-// determine if the Retry loop should make another attempt.
+// determine if the retry loop should make another attempt.
 func shouldAttempt(attempt uint, strategies ...strategy.Strategy) bool {
-	shouldAttempt := true
+	return haltingStrategy(attempt, strategies...) < 0
+}
 
-	for i := 0; shouldAttempt && i < len(strategies); i++ {
-		shouldAttempt = shouldAttempt && strategies[i](attempt)
+// haltingStrategy evaluates the provided strategies with the given attempt, in
+// order, and returns the index of the first strategy to halt the retry loop, or
+// -1 if none do.
+func haltingStrategy(attempt uint, strategies ...strategy.Strategy) int {
+	for i, strategy := range strategies {
+		if !strategy(attempt) {
+			return i
+		}
 	}
 
-	return shouldAttempt
+	return -1
 }
@@ -3,6 +3,8 @@ package retry
 import (
 	"errors"
 	"testing"
+
+	"github.com/Rican7/retry/strategy"
 )
 
 func TestRetry(t *testing.T) {
@@ -84,6 +86,46 @@ func TestRetryRetriesUntilNoErrorReturned(t *testing.T) {
 	}
 }
 
+func TestRetryWithFactories(t *testing.T) {
+	const budget = 3
+
+	var factoryCalls int
+
+	factory := strategy.FactoryFunc(func() strategy.Strategy {
+		factoryCalls++
+
+		remaining := budget
+
+		return func(attempt uint) bool {
+			remaining--
+
+			return remaining >= 0
+		}
+	})
+
+	for i := 1; i <= 2; i++ {
+		var attemptsMade uint
+
+		err := RetryWithFactories(func(attempt uint) error {
+			attemptsMade = attempt
+
+			return errors.New("erroring")
+		}, factory, strategy.Limit(5))
+
+		if err == nil {
+			t.Error("expected a non-nil error")
+		}
+
+		if attemptsMade != budget {
+			t.Errorf("expected %d attempts to be made, but %d were made instead", budget, attemptsMade)
+		}
+
+		if factoryCalls != i {
+			t.Errorf("expected the factory to be called %d times, but it was called %d times", i, factoryCalls)
+		}
+	}
+}
+
 func TestShouldAttempt(t *testing.T) {
 	shouldAttempt := shouldAttempt(1)
 
@@ -157,3 +199,17 @@ func TestShouldAttemptWithMultipleStrategies(t *testing.T) {
 		t.Error("expected to return false")
 	}
 }
+
+func BenchmarkRetry(b *testing.B) {
+	action := func(attempt uint) error {
+		return nil
+	}
+
+	strategies := []strategy.Strategy{strategy.Limit(3), strategy.Wait(0)}
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_ = Retry(action, strategies...)
+	}
+}
@@ -0,0 +1,138 @@
+// Package retryio provides io.Reader and io.Writer implementations that retry
+// failed reads and writes, resuming where they left off.
+//
+// Copyright © 2026 Trevor N. Suarez (Rican7)
+package retryio
+
+import (
+	"io"
+
+	"github.com/Rican7/retry"
+	"github.com/Rican7/retry/strategy"
+)
+
+// Opener defines a function that opens a source for reading, starting at the
+// given byte offset.
+type Opener func(offset int64) (io.ReadCloser, error)
+
+// Reader is an io.ReadCloser that reopens its source at the current offset when
+// a read fails, so that reading continues where it left off.
+type Reader struct {
+	open       Opener
+	strategies []strategy.Strategy
+	source     io.ReadCloser
+	offset     int64
+}
+
+// NewReader creates a Reader that opens its source with the given Opener.
+//
+// Optionally, strategies may be passed that assess whether or not an attempt to
+// (re)open and read from the source should be made. The strategies are used
+// anew for every call to Read.
+func NewReader(open Opener, strategies ...strategy.Strategy) *Reader {
+	return &Reader{
+		open:       open,
+		strategies: strategies,
+	}
+}
+
+// Read reads from the source, (re)opening it as necessary. If a read fails
+// after reading some data, that data is returned, and the source is reopened
+// on the next call to Read.
+func (r *Reader) Read(p []byte) (int, error) {
+	var n int
+	var readErr error
+
+	err := retry.Retry(func(attempt uint) error {
+		if r.source == nil {
+			source, err := r.open(r.offset)
+
+			if err != nil {
+				return err
+			}
+
+			r.source = source
+		}
+
+		n, readErr = r.source.Read(p)
+		r.offset += int64(n)
+
+		if readErr == nil || readErr == io.EOF {
+			return nil
+		}
+
+		// Discard the failed source, to reopen it at the new offset
+		r.source.Close()
+		r.source = nil
+
+		if n > 0 {
+			readErr = nil
+
+			return nil
+		}
+
+		return readErr
+	}, r.strategies...)
+
+	if err != nil {
+		return n, err
+	}
+
+	return n, readErr
+}
+
+// Offset returns the number of bytes read so far, which is the offset that the
+// source is reopened at.
+func (r *Reader) Offset() int64 {
+	return r.offset
+}
+
+// Close closes the currently open source, if any.
+func (r *Reader) Close() error {
+	if r.source == nil {
+		return nil
+	}
+
+	err := r.source.Close()
+	r.source = nil
+
+	return err
+}
+
+// Writer is an io.Writer that retries failed or short writes, writing only the
+// remaining data on each attempt.
+type Writer struct {
+	writer     io.Writer
+	strategies []strategy.Strategy
+}
+
+// NewWriter creates a Writer that writes to the given io.Writer.
+//
+// Optionally, strategies may be passed that assess whether or not an attempt to
+// write should be made. The strategies are used anew for every call to Write.
+func NewWriter(writer io.Writer, strategies ...strategy.Strategy) *Writer {
+	return &Writer{
+		writer:     writer,
+		strategies: strategies,
+	}
+}
+
+// Write writes the given data, retrying until all of it has been written. It
+// returns the number of bytes written, which is less than len(p) only if it
+// also returns an error.
+func (w *Writer) Write(p []byte) (int, error) {
+	var written int
+
+	err := retry.Retry(func(attempt uint) error {
+		n, err := w.writer.Write(p[written:])
+		written += n
+
+		if err == nil && written < len(p) {
+			err = io.ErrShortWrite
+		}
+
+		return err
+	}, w.strategies...)
+
+	return written, err
+}
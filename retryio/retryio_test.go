@@ -0,0 +1,238 @@
+package retryio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Rican7/retry/strategy"
+)
+
+var errDropped = errors.New("connection dropped")
+
+// flakyReader reads from data until a failure offset is reached.
+type flakyReader struct {
+	data     []byte
+	offset   int64
+	failAt   int64
+	closed   bool
+	maxChunk int
+}
+
+func (r *flakyReader) Read(p []byte) (int, error) {
+	if r.offset >= int64(len(r.data)) {
+		return 0, io.EOF
+	}
+
+	if r.failAt >= 0 && r.offset >= r.failAt {
+		return 0, errDropped
+	}
+
+	end := int64(len(r.data))
+
+	if r.failAt >= 0 && r.failAt < end {
+		end = r.failAt
+	}
+
+	if r.maxChunk > 0 && end-r.offset > int64(r.maxChunk) {
+		end = r.offset + int64(r.maxChunk)
+	}
+
+	n := copy(p, r.data[r.offset:end])
+	r.offset += int64(n)
+
+	return n, nil
+}
+
+func (r *flakyReader) Close() error {
+	r.closed = true
+
+	return nil
+}
+
+// flakyOpener creates an Opener for the given data, where each opened source
+// fails at the next of the given offsets.
+func flakyOpener(data []byte, failAt []int64, opened *[]*flakyReader) Opener {
+	return func(offset int64) (io.ReadCloser, error) {
+		reader := &flakyReader{data: data, offset: offset, failAt: -1, maxChunk: 4}
+
+		if len(*opened) < len(failAt) {
+			reader.failAt = failAt[len(*opened)]
+		}
+
+		*opened = append(*opened, reader)
+
+		return reader, nil
+	}
+}
+
+func TestReader(t *testing.T) {
+	const data = "the quick brown fox jumps over the lazy dog"
+
+	var opened []*flakyReader
+
+	reader := NewReader(flakyOpener([]byte(data), []int64{5, 5, 17, 30}, &opened), strategy.Limit(3))
+
+	result, err := io.ReadAll(reader)
+
+	if err != nil {
+		t.Fatalf("expected a nil error, received %q instead", err)
+	}
+
+	if string(result) != data {
+		t.Errorf("expected to read %q, read %q instead", data, result)
+	}
+
+	if reader.Offset() != int64(len(data)) {
+		t.Errorf("expected an offset of %d, received %d instead", len(data), reader.Offset())
+	}
+
+	expectedOffsets := []int64{0, 5, 5, 17, 30}
+
+	if len(opened) != len(expectedOffsets) {
+		t.Fatalf("expected the source to be opened %d times, but it was opened %d times", len(expectedOffsets), len(opened))
+	}
+
+	for i, source := range opened[:len(opened)-1] {
+		if !source.closed {
+			t.Errorf("expected failed source %d to be closed", i)
+		}
+	}
+
+	if err := reader.Close(); err != nil || !opened[len(opened)-1].closed {
+		t.Error("expected the last source to be closed")
+	}
+}
+
+func TestReaderExhausted(t *testing.T) {
+	const attemptLimit = 3
+
+	var opened []*flakyReader
+
+	// Every source fails at the same offset
+	reader := NewReader(
+		flakyOpener([]byte("0123456789"), []int64{4, 4, 4, 4, 4}, &opened),
+		strategy.Limit(attemptLimit),
+	)
+
+	result, err := io.ReadAll(reader)
+
+	if err != errDropped {
+		t.Errorf("expected the read error, received %q instead", err)
+	}
+
+	if string(result) != "0123" {
+		t.Errorf("expected to read %q, read %q instead", "0123", result)
+	}
+}
+
+func TestReaderOpenFailure(t *testing.T) {
+	errUnavailable := errors.New("unavailable")
+
+	var opens int
+
+	reader := NewReader(func(offset int64) (io.ReadCloser, error) {
+		opens++
+
+		if opens < 3 {
+			return nil, errUnavailable
+		}
+
+		return io.NopCloser(strings.NewReader("data")), nil
+	})
+
+	result, err := io.ReadAll(reader)
+
+	if err != nil {
+		t.Errorf("expected a nil error, received %q instead", err)
+	}
+
+	if string(result) != "data" {
+		t.Errorf("expected to read %q, read %q instead", "data", result)
+	}
+}
+
+func TestReaderCloseUnopened(t *testing.T) {
+	reader := NewReader(nil)
+
+	if err := reader.Close(); err != nil {
+		t.Errorf("expected a nil error, received %q instead", err)
+	}
+}
+
+// flakyWriter writes at most a chunk of data at a time, failing every other
+// write.
+type flakyWriter struct {
+	bytes.Buffer
+	chunk  int
+	writes int
+}
+
+func (w *flakyWriter) Write(p []byte) (int, error) {
+	w.writes++
+
+	if w.writes%2 == 0 {
+		return 0, errDropped
+	}
+
+	if len(p) > w.chunk {
+		p = p[:w.chunk]
+	}
+
+	return w.Buffer.Write(p)
+}
+
+func TestWriter(t *testing.T) {
+	const data = "the quick brown fox"
+
+	destination := &flakyWriter{chunk: 5}
+	writer := NewWriter(destination)
+
+	n, err := writer.Write([]byte(data))
+
+	if err != nil {
+		t.Errorf("expected a nil error, received %q instead", err)
+	}
+
+	if n != len(data) {
+		t.Errorf("expected %d bytes to be written, but %d were written instead", len(data), n)
+	}
+
+	if destination.String() != data {
+		t.Errorf("expected %q to be written, but %q was written instead", data, destination.String())
+	}
+}
+
+func TestWriterExhausted(t *testing.T) {
+	const data = "the quick brown fox"
+
+	destination := &flakyWriter{chunk: 5}
+	writer := NewWriter(destination, strategy.Limit(2))
+
+	n, err := writer.Write([]byte(data))
+
+	if !errors.Is(err, errDropped) {
+		t.Errorf("expected the write error, received %q instead", err)
+	}
+
+	if n != 5 || destination.String() != data[:5] {
+		t.Errorf("expected 5 bytes to be written, but %q was written instead", destination.String())
+	}
+}
+
+func TestWriterShortWrite(t *testing.T) {
+	destination := &flakyWriter{chunk: 1}
+	writer := NewWriter(destination, strategy.Limit(1))
+
+	n, err := writer.Write([]byte("data"))
+
+	if !errors.Is(err, io.ErrShortWrite) {
+		t.Errorf("expected a short write error, received %q instead", err)
+	}
+
+	if n != 1 {
+		t.Errorf("expected 1 byte to be written, but %d were written instead", n)
+	}
+}
@@ -0,0 +1,101 @@
+// Package retrynet provides a way to retry network dials that fail with
+// retryable errors, such as when a service isn't yet accepting connections.
+//
+// Copyright © 2026 Trevor N. Suarez (Rican7)
+package retrynet
+
+import (
+	"context"
+	"errors"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/Rican7/retry"
+	"github.com/Rican7/retry/strategy"
+)
+
+// dialFunc defines a function that dials a network address.
+type dialFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// Dialer dials network addresses, retrying dials that fail with retryable
+// errors (as reported by IsRetryable).
+//
+// Its DialContext method may be used as the DialContext of an http.Transport.
+type Dialer struct {
+	dial    dialFunc
+	timeout time.Duration
+	policy  *retry.Policy
+}
+
+// NewDialer creates a Dialer that dials with the given *net.Dialer. If a nil
+// dialer is passed, a default one will be provided.
+//
+// If the given timeout is non-zero, each dial attempt is limited to it, in
+// addition to any timeout of the dialer itself.
+//
+// Optionally, strategies may be passed that assess whether or not a dial
+// attempt should be made.
+func NewDialer(dialer *net.Dialer, timeout time.Duration, strategies ...strategy.Strategy) *Dialer {
+	if dialer == nil {
+		dialer = &net.Dialer{}
+	}
+
+	return &Dialer{
+		dial:    dialer.DialContext,
+		timeout: timeout,
+		policy: retry.NewPolicy(
+			retry.WithStrategies(strategies...),
+			retry.WithClassifiers(IsRetryable),
+		),
+	}
+}
+
+// Dial connects to the address on the named network, retrying as necessary.
+func (d *Dialer) Dial(network, address string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, address)
+}
+
+// DialContext connects to the address on the named network using the given
+// context, retrying as necessary.
+func (d *Dialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	var conn net.Conn
+
+	err := d.policy.DoContext(ctx, func(attempt uint) error {
+		dialCtx := ctx
+
+		if d.timeout > 0 {
+			var cancel context.CancelFunc
+
+			dialCtx, cancel = context.WithTimeout(ctx, d.timeout)
+			defer cancel()
+		}
+
+		var err error
+
+		conn, err = d.dial(dialCtx, network, address)
+
+		return err
+	})
+
+	return conn, err
+}
+
+// IsRetryable reports whether the given dial error is worth retrying. Refused
+// and reset connections, timeouts, and temporary DNS failures are considered
+// retryable.
+func IsRetryable(err error) bool {
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	var dnsErr *net.DNSError
+
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary || dnsErr.IsTimeout
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,194 @@
+package retrynet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/Rican7/retry/strategy"
+)
+
+// closedAddress returns a loopback address that nothing is listening on.
+func closedAddress(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatalf("unable to listen: %s", err)
+	}
+
+	address := listener.Addr().String()
+	listener.Close()
+
+	return address
+}
+
+func TestDialer(t *testing.T) {
+	address := closedAddress(t)
+
+	// Start listening once some dials have been refused
+	listening := make(chan net.Listener, 1)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+
+		listener, err := net.Listen("tcp", address)
+
+		if err != nil {
+			t.Errorf("unable to listen: %s", err)
+		}
+
+		listening <- listener
+	}()
+
+	dialer := NewDialer(nil, time.Second, strategy.Wait(5*time.Millisecond), strategy.Limit(100))
+
+	conn, err := dialer.Dial("tcp", address)
+
+	if listener := <-listening; listener != nil {
+		defer listener.Close()
+	}
+
+	if err != nil {
+		t.Fatalf("expected a nil error, received %q instead", err)
+	}
+
+	conn.Close()
+}
+
+func TestDialerExhausted(t *testing.T) {
+	const attemptLimit = 3
+
+	address := closedAddress(t)
+
+	dialer := NewDialer(&net.Dialer{}, 0, strategy.Limit(attemptLimit))
+
+	_, err := dialer.DialContext(context.Background(), "tcp", address)
+
+	if !errors.Is(err, syscall.ECONNREFUSED) {
+		t.Errorf("expected a connection refused error, received %q instead", err)
+	}
+}
+
+func TestDialerNotRetryable(t *testing.T) {
+	var dials int
+
+	dialer := NewDialer(nil, 0, strategy.Limit(3))
+	dialer.dial = func(ctx context.Context, network, address string) (net.Conn, error) {
+		dials++
+
+		return nil, errors.New("unknown network")
+	}
+
+	if _, err := dialer.Dial("tcp", "example.invalid:80"); err == nil {
+		t.Error("expected a non-nil error")
+	}
+
+	if dials != 1 {
+		t.Errorf("expected 1 dial, but %d were made", dials)
+	}
+}
+
+func TestDialerTimeout(t *testing.T) {
+	const timeout = 5 * time.Millisecond
+	const attemptLimit = 3
+
+	var dials int
+
+	dialer := NewDialer(nil, timeout, strategy.Limit(attemptLimit))
+	dialer.dial = func(ctx context.Context, network, address string) (net.Conn, error) {
+		dials++
+
+		if _, ok := ctx.Deadline(); !ok {
+			t.Error("expected the dial context to have a deadline")
+		}
+
+		<-ctx.Done()
+
+		return nil, &net.OpError{Op: "dial", Net: network, Err: ctx.Err()}
+	}
+
+	_, err := dialer.Dial("tcp", "192.0.2.1:80")
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected a deadline exceeded error, received %q instead", err)
+	}
+
+	if dials != attemptLimit {
+		t.Errorf("expected %d dials, but %d were made", attemptLimit, dials)
+	}
+}
+
+func TestDialerContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	dialer := NewDialer(nil, 0)
+	dialer.dial = func(ctx context.Context, network, address string) (net.Conn, error) {
+		cancel()
+
+		return nil, &net.OpError{Op: "dial", Net: network, Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}
+	}
+
+	if _, err := dialer.DialContext(ctx, "tcp", "127.0.0.1:1"); err != context.Canceled {
+		t.Errorf("expected a context canceled error, received %q instead", err)
+	}
+}
+
+func TestDialerHTTPTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	defer server.Close()
+
+	dialer := NewDialer(nil, time.Second, strategy.Limit(3))
+	client := &http.Client{Transport: &http.Transport{DialContext: dialer.DialContext}}
+
+	response, err := client.Get(server.URL)
+
+	if err != nil {
+		t.Fatalf("expected a nil error, received %q instead", err)
+	}
+
+	defer response.Body.Close()
+
+	if body, _ := io.ReadAll(response.Body); string(body) != "ok" {
+		t.Errorf("expected the body %q, received %q instead", "ok", body)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	retryable := []error{
+		&net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)},
+		fmt.Errorf("wrapped: %w", syscall.ECONNRESET),
+		&net.OpError{Op: "dial", Err: context.DeadlineExceeded},
+		&net.DNSError{Err: "server misbehaving", IsTemporary: true},
+		&net.DNSError{Err: "timeout", IsTimeout: true},
+	}
+
+	for _, err := range retryable {
+		if !IsRetryable(err) {
+			t.Errorf("expected %q to be retryable", err)
+		}
+	}
+
+	notRetryable := []error{
+		&net.DNSError{Err: "no such host", IsNotFound: true},
+		&net.AddrError{Err: "missing port in address"},
+		errors.New("other"),
+	}
+
+	for _, err := range notRetryable {
+		if IsRetryable(err) {
+			t.Errorf("expected %q to not be retryable", err)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+// Package retrysql provides a way to retry database/sql operations that fail
+// with transient errors, such as dropped connections, serialization failures,
+// and deadlocks.
+//
+// Copyright © 2026 Trevor N. Suarez (Rican7)
+package retrysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	"github.com/Rican7/retry"
+	"github.com/Rican7/retry/backoff"
+	"github.com/Rican7/retry/jitter"
+	"github.com/Rican7/retry/strategy"
+)
+
+// SQLSTATE codes of common transient errors.
+const (
+	SerializationFailure = "40001"
+	DeadlockDetected     = "40P01"
+)
+
+// DB wraps a *sql.DB, retrying its operations according to a retry.Policy.
+//
+// The Policy's classifiers determine which errors are transient, and therefore
+// retried. IsTransient is a sensible default:
+//
+//	db := retrysql.New(sqlDB, retry.NewPolicy(
+//		retry.WithStrategies(strategy.Limit(3)),
+//		retry.WithClassifiers(retrysql.IsTransient),
+//	))
+type DB struct {
+	db     *sql.DB
+	policy *retry.Policy
+}
+
+// defaultPolicy is the Policy used by a DB created with a nil Policy.
+var defaultPolicy = retry.NewPolicy(
+	retry.WithStrategies(
+		strategy.Limit(5),
+		strategy.BackoffWithJitter(backoff.Exponential(10*time.Millisecond, 2), jitter.Full(nil)),
+	),
+	retry.WithClassifiers(IsTransient),
+)
+
+// New creates a DB that wraps the given *sql.DB and retries its operations
+// according to the given Policy. If a nil Policy is passed, a Policy that
+// retries the errors deemed transient by IsTransient will be used, making up to
+// 5 attempts, with an exponential backoff (with full jitter) from 10ms.
+func New(db *sql.DB, policy *retry.Policy) *DB {
+	if policy == nil {
+		policy = defaultPolicy
+	}
+
+	return &DB{
+		db:     db,
+		policy: policy,
+	}
+}
+
+// DB returns the wrapped *sql.DB.
+func (db *DB) DB() *sql.DB {
+	return db.db
+}
+
+// ExecContext executes a query without returning any rows, as with
+// sql.DB.ExecContext, retrying it according to the Policy.
+func (db *DB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	var result sql.Result
+
+	err := db.policy.DoContext(ctx, func(attempt uint) error {
+		var err error
+
+		result, err = db.db.ExecContext(ctx, query, args...)
+
+		return err
+	})
+
+	return result, err
+}
+
+// QueryContext executes a query that returns rows, as with
+// sql.DB.QueryContext, retrying it according to the Policy.
+//
+// Only the query itself is retried; errors encountered while iterating over the
+// returned rows are not.
+func (db *DB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	var rows *sql.Rows
+
+	err := db.policy.DoContext(ctx, func(attempt uint) error {
+		var err error
+
+		rows, err = db.db.QueryContext(ctx, query, args...)
+
+		return err
+	})
+
+	return rows, err
+}
+
+// Tx runs the given function within a transaction, committing the transaction
+// if the function succeeds and rolling it back otherwise (even if the function
+// panics). The whole transaction
+// is retried, by running the function again in a new transaction, according to
+// the Policy.
+//
+// As the function may be run more than once, it shouldn't have side effects
+// outside of the transaction.
+func (db *DB) Tx(ctx context.Context, opts *sql.TxOptions, fn func(tx *sql.Tx) error) error {
+	return db.policy.DoContext(ctx, func(attempt uint) error {
+		tx, err := db.db.BeginTx(ctx, opts)
+
+		if err != nil {
+			return err
+		}
+
+		// Rolling back a committed transaction does nothing
+		defer tx.Rollback()
+
+		if err := fn(tx); err != nil {
+			return err
+		}
+
+		return tx.Commit()
+	})
+}
+
+// IsBadConn reports whether the given error is (or wraps) driver.ErrBadConn.
+func IsBadConn(err error) bool {
+	return errors.Is(err, driver.ErrBadConn)
+}
+
+// SQLStates creates a retry.Classifier that reports whether an error has one of
+// the given SQLSTATE codes, as reported by a `SQLState() string` method on the
+// error (or any error that it wraps), as the errors of many drivers have.
+func SQLStates(codes ...string) retry.Classifier {
+	return func(err error) bool {
+		var stater interface {
+			SQLState() string
+		}
+
+		if !errors.As(err, &stater) {
+			return false
+		}
+
+		state := stater.SQLState()
+
+		for _, code := range codes {
+			if state == code {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// isTransientState reports whether an error has a transient SQLSTATE code.
+var isTransientState = SQLStates(SerializationFailure, DeadlockDetected)
+
+// IsTransient reports whether the given error is likely to be transient, and
+// therefore worth retrying. Bad connections, serialization failures, and
+// deadlocks are considered transient.
+func IsTransient(err error) bool {
+	return IsBadConn(err) || isTransientState(err)
+}
@@ -0,0 +1,443 @@
+package retrysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/Rican7/retry"
+	"github.com/Rican7/retry/strategy"
+)
+
+// stateError is an error with a SQLSTATE code.
+type stateError string
+
+func (e stateError) Error() string {
+	return "sqlstate " + string(e)
+}
+
+func (e stateError) SQLState() string {
+	return string(e)
+}
+
+// fakeDriver is a database/sql driver that fails on demand.
+type fakeDriver struct {
+	mutex     sync.Mutex
+	errs      []error
+	calls     []string
+	commits   int
+	rollbacks int
+}
+
+// fail queues the given errors to be returned by the next operations.
+func (d *fakeDriver) fail(errs ...error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.errs = append(d.errs, errs...)
+}
+
+// next records an operation and returns its queued error, if any.
+func (d *fakeDriver) next(call string) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.calls = append(d.calls, call)
+
+	if len(d.errs) == 0 {
+		return nil
+	}
+
+	err := d.errs[0]
+	d.errs = d.errs[1:]
+
+	return err
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return &fakeConn{driver: d}, nil
+}
+
+func (d *fakeDriver) Connect(ctx context.Context) (driver.Conn, error) {
+	return &fakeConn{driver: d}, nil
+}
+
+func (d *fakeDriver) Driver() driver.Driver {
+	return d
+}
+
+type fakeConn struct {
+	driver *fakeDriver
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepare not supported")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *fakeConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if err := c.driver.next("begin"); err != nil {
+		return nil, err
+	}
+
+	return &fakeTx{driver: c.driver}, nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if err := c.driver.next("exec " + query); err != nil {
+		return nil, err
+	}
+
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if err := c.driver.next("query " + query); err != nil {
+		return nil, err
+	}
+
+	return &fakeRows{}, nil
+}
+
+type fakeTx struct {
+	driver *fakeDriver
+}
+
+func (tx *fakeTx) Commit() error {
+	if err := tx.driver.next("commit"); err != nil {
+		return err
+	}
+
+	tx.driver.mutex.Lock()
+	defer tx.driver.mutex.Unlock()
+
+	tx.driver.commits++
+
+	return nil
+}
+
+func (tx *fakeTx) Rollback() error {
+	tx.driver.mutex.Lock()
+	defer tx.driver.mutex.Unlock()
+
+	tx.driver.rollbacks++
+
+	return nil
+}
+
+// fakeRows is a single row with a single "value" column.
+type fakeRows struct {
+	done bool
+}
+
+func (r *fakeRows) Columns() []string {
+	return []string{"value"}
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+
+	r.done = true
+	dest[0] = int64(42)
+
+	return nil
+}
+
+// newTestDB creates a DB backed by a fakeDriver.
+func newTestDB(t *testing.T, attemptLimit uint) (*DB, *fakeDriver) {
+	t.Helper()
+
+	fake := &fakeDriver{}
+	sqlDB := sql.OpenDB(fake)
+
+	t.Cleanup(func() {
+		sqlDB.Close()
+	})
+
+	policy := retry.NewPolicy(
+		retry.WithStrategies(strategy.Limit(attemptLimit)),
+		retry.WithClassifiers(IsTransient),
+	)
+
+	return New(sqlDB, policy), fake
+}
+
+func TestExecContext(t *testing.T) {
+	db, fake := newTestDB(t, 3)
+
+	fake.fail(stateError(SerializationFailure), stateError(DeadlockDetected))
+
+	result, err := db.ExecContext(context.Background(), "UPDATE things")
+
+	if err != nil {
+		t.Fatalf("expected a nil error, received %q instead", err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected != 1 {
+		t.Errorf("expected 1 row to be affected, received %d instead", affected)
+	}
+
+	if len(fake.calls) != 3 {
+		t.Errorf("expected 3 calls, received %v instead", fake.calls)
+	}
+}
+
+func TestExecContextPermanentError(t *testing.T) {
+	db, fake := newTestDB(t, 3)
+
+	errSyntax := stateError("42601")
+
+	fake.fail(errSyntax)
+
+	_, err := db.ExecContext(context.Background(), "UPDATE")
+
+	if !errors.Is(err, errSyntax) {
+		t.Errorf("expected the syntax error, received %q instead", err)
+	}
+
+	if len(fake.calls) != 1 {
+		t.Errorf("expected 1 call, received %v instead", fake.calls)
+	}
+}
+
+func TestQueryContext(t *testing.T) {
+	db, fake := newTestDB(t, 3)
+
+	fake.fail(stateError(SerializationFailure))
+
+	rows, err := db.QueryContext(context.Background(), "SELECT value")
+
+	if err != nil {
+		t.Fatalf("expected a nil error, received %q instead", err)
+	}
+
+	defer rows.Close()
+
+	var value int
+
+	for rows.Next() {
+		rows.Scan(&value)
+	}
+
+	if value != 42 {
+		t.Errorf("expected to scan 42, received %d instead", value)
+	}
+
+	if len(fake.calls) != 2 {
+		t.Errorf("expected 2 calls, received %v instead", fake.calls)
+	}
+}
+
+func TestQueryContextExhausted(t *testing.T) {
+	db, fake := newTestDB(t, 2)
+
+	fake.fail(stateError(DeadlockDetected), stateError(DeadlockDetected), stateError(DeadlockDetected))
+
+	_, err := db.QueryContext(context.Background(), "SELECT value")
+
+	if err != stateError(DeadlockDetected) {
+		t.Errorf("expected the last error, received %q instead", err)
+	}
+
+	if len(fake.calls) != 2 {
+		t.Errorf("expected 2 calls, received %v instead", fake.calls)
+	}
+}
+
+func TestTx(t *testing.T) {
+	db, fake := newTestDB(t, 5)
+
+	// Fail the begin, then the statement, then the commit
+	fake.fail(
+		stateError(SerializationFailure),
+		nil,
+		stateError(DeadlockDetected),
+		nil,
+		nil,
+		stateError(SerializationFailure),
+	)
+
+	var runs int
+
+	err := db.Tx(context.Background(), nil, func(tx *sql.Tx) error {
+		runs++
+
+		_, err := tx.ExecContext(context.Background(), "INSERT thing")
+
+		return err
+	})
+
+	if err != nil {
+		t.Fatalf("expected a nil error, received %q instead", err)
+	}
+
+	if runs != 3 {
+		t.Errorf("expected the transaction to run 3 times, but it ran %d times", runs)
+	}
+
+	if fake.commits != 1 {
+		t.Errorf("expected 1 commit, received %d instead", fake.commits)
+	}
+
+	if fake.rollbacks != 1 {
+		t.Errorf("expected 1 rollback, received %d instead", fake.rollbacks)
+	}
+
+	expectedCalls := fmt.Sprint([]string{
+		"begin",
+		"begin", "exec INSERT thing",
+		"begin", "exec INSERT thing", "commit",
+		"begin", "exec INSERT thing", "commit",
+	})
+
+	if calls := fmt.Sprint(fake.calls); calls != expectedCalls {
+		t.Errorf("expected the calls %s, received %s instead", expectedCalls, calls)
+	}
+}
+
+func TestTxFunctionError(t *testing.T) {
+	db, fake := newTestDB(t, 5)
+
+	errInvalid := errors.New("invalid thing")
+
+	var runs int
+
+	err := db.Tx(context.Background(), nil, func(tx *sql.Tx) error {
+		runs++
+
+		return errInvalid
+	})
+
+	if err != errInvalid {
+		t.Errorf("expected the function's error, received %q instead", err)
+	}
+
+	if runs != 1 {
+		t.Errorf("expected the transaction to run once, but it ran %d times", runs)
+	}
+
+	if fake.rollbacks != 1 {
+		t.Errorf("expected 1 rollback, received %d instead", fake.rollbacks)
+	}
+}
+
+func TestTxFunctionPanic(t *testing.T) {
+	db, fake := newTestDB(t, 5)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected the function's panic to be propagated")
+			}
+		}()
+
+		db.Tx(context.Background(), nil, func(tx *sql.Tx) error {
+			panic("oops")
+		})
+	}()
+
+	if fake.rollbacks != 1 {
+		t.Errorf("expected 1 rollback, received %d instead", fake.rollbacks)
+	}
+}
+
+func TestNewNilPolicy(t *testing.T) {
+	fake := &fakeDriver{}
+	sqlDB := sql.OpenDB(fake)
+	defer sqlDB.Close()
+
+	db := New(sqlDB, nil)
+
+	fake.fail(stateError(SerializationFailure), stateError(DeadlockDetected))
+
+	if _, err := db.ExecContext(context.Background(), "UPDATE things"); err != nil {
+		t.Fatalf("expected a nil error, received %q instead", err)
+	}
+
+	if len(fake.calls) != 3 {
+		t.Errorf("expected 3 calls, received %v instead", fake.calls)
+	}
+
+	fake.fail(errors.New("syntax error"))
+
+	if _, err := db.ExecContext(context.Background(), "UPDATE things"); err == nil {
+		t.Error("expected a non-transient error to not be retried")
+	}
+
+	fake.calls = nil
+
+	for i := 0; i < 10; i++ {
+		fake.fail(stateError(SerializationFailure))
+	}
+
+	if _, err := db.ExecContext(context.Background(), "UPDATE things"); err == nil {
+		t.Error("expected the retrying process to be bounded")
+	}
+
+	if len(fake.calls) != 5 {
+		t.Errorf("expected 5 calls, received %v instead", fake.calls)
+	}
+}
+
+func TestDBAccessor(t *testing.T) {
+	sqlDB := sql.OpenDB(&fakeDriver{})
+	defer sqlDB.Close()
+
+	if New(sqlDB, retry.NewPolicy()).DB() != sqlDB {
+		t.Error("expected the wrapped *sql.DB")
+	}
+}
+
+func TestIsBadConn(t *testing.T) {
+	if !IsBadConn(fmt.Errorf("wrapped: %w", driver.ErrBadConn)) {
+		t.Error("expected a bad connection error to be detected")
+	}
+
+	if IsBadConn(errors.New("other")) {
+		t.Error("expected another error to not be detected")
+	}
+}
+
+func TestSQLStates(t *testing.T) {
+	classifier := SQLStates("40001", "55P03")
+
+	if !classifier(stateError("55P03")) || !classifier(fmt.Errorf("wrapped: %w", stateError("40001"))) {
+		t.Error("expected matching states to be classified")
+	}
+
+	if classifier(stateError("23505")) || classifier(errors.New("no state")) {
+		t.Error("expected other errors to not be classified")
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	transient := []error{driver.ErrBadConn, stateError(SerializationFailure), stateError(DeadlockDetected)}
+
+	for _, err := range transient {
+		if !IsTransient(err) {
+			t.Errorf("expected %q to be transient", err)
+		}
+	}
+
+	if IsTransient(stateError("23505")) || IsTransient(sql.ErrNoRows) {
+		t.Error("expected other errors to not be transient")
+	}
+}
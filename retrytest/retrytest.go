@@ -0,0 +1,199 @@
+// Package retrytest provides utilities for testing code that retries actions,
+// such as scripted actions, a recorder of attempts, and a fake clock.
+//
+// Copyright © 2026 Trevor N. Suarez (Rican7)
+package retrytest
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Rican7/retry"
+	"github.com/Rican7/retry/clock"
+)
+
+// FailN creates an Action that returns the given error for its first n
+// attempts, and succeeds afterwards.
+func FailN(n uint, err error) retry.Action {
+	return func(attempt uint) error {
+		if attempt <= n {
+			return err
+		}
+
+		return nil
+	}
+}
+
+// Sequence creates an Action that returns the given results in order, one per
+// attempt. Once the results run out, the last result is returned for every
+// further attempt. No results always succeeds.
+func Sequence(results ...error) retry.Action {
+	return func(attempt uint) error {
+		if len(results) == 0 {
+			return nil
+		}
+
+		resultIndex := int(attempt - 1)
+
+		if len(results) <= resultIndex {
+			resultIndex = len(results) - 1
+		}
+
+		return results[resultIndex]
+	}
+}
+
+// Call describes a single call of an Action that was recorded.
+type Call struct {
+	// Attempt is the attempt number that the Action was called with.
+	Attempt uint
+
+	// Time is the time that the Action was called.
+	Time time.Time
+}
+
+// Recorder records the calls made to the actions that it wraps. It's safe for
+// concurrent use.
+type Recorder struct {
+	clock clock.Clock
+
+	mutex sync.Mutex
+	calls []Call
+}
+
+// NewRecorder creates a Recorder that tells the time of calls with the given
+// clock. A nil clock uses the system's time.
+func NewRecorder(c clock.Clock) *Recorder {
+	if c == nil {
+		c = clock.System()
+	}
+
+	return &Recorder{clock: c}
+}
+
+// Record wraps the given action so that each of its calls is recorded.
+func (r *Recorder) Record(action retry.Action) retry.Action {
+	return func(attempt uint) error {
+		r.record(Call{Attempt: attempt, Time: r.clock.Now()})
+
+		return action(attempt)
+	}
+}
+
+// Hook creates a retry.Hook that records each attempt made by a retry.Policy,
+// as of the time that the attempt started.
+func (r *Recorder) Hook() retry.Hook {
+	return func(attempt retry.Attempt) {
+		r.record(Call{Attempt: attempt.Number, Time: attempt.Start})
+	}
+}
+
+// Calls returns the calls recorded so far, in the order that they were made.
+func (r *Recorder) Calls() []Call {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]Call(nil), r.calls...)
+}
+
+// Delays returns the durations between each of the calls recorded so far.
+func (r *Recorder) Delays() []time.Duration {
+	calls := r.Calls()
+
+	if len(calls) < 2 {
+		return nil
+	}
+
+	delays := make([]time.Duration, len(calls)-1)
+
+	for i := range delays {
+		delays[i] = calls[i+1].Time.Sub(calls[i].Time)
+	}
+
+	return delays
+}
+
+// AssertAttempts reports a test error if the number of calls recorded isn't
+// the given number.
+func (r *Recorder) AssertAttempts(t testing.TB, expected int) {
+	t.Helper()
+
+	if calls := len(r.Calls()); calls != expected {
+		t.Errorf("expected %d attempts to be made, but %d were made instead", expected, calls)
+	}
+}
+
+// AssertDelays reports a test error if the durations between the calls
+// recorded aren't exactly the given durations. Exact durations are only
+// reliable when using a fake clock, such as a Clock.
+func (r *Recorder) AssertDelays(t testing.TB, expected ...time.Duration) {
+	t.Helper()
+
+	delays := r.Delays()
+
+	if len(delays) != len(expected) {
+		t.Errorf("expected %d delays, received %d instead: %v", len(expected), len(delays), delays)
+
+		return
+	}
+
+	for i, delay := range delays {
+		if delay != expected[i] {
+			t.Errorf("expected delay #%d to be %s, received %s instead", i+1, expected[i], delay)
+		}
+	}
+}
+
+// record appends the given call to the recorded calls.
+func (r *Recorder) record(call Call) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.calls = append(r.calls, call)
+}
+
+// Clock is a fake clock.Clock whose time only moves when it's told to, or when
+// it's waited on. Waiting on a Clock returns immediately, after advancing its
+// time by the duration waited, so that tests run instantly. It's safe for
+// concurrent use.
+//
+// The strategies of package strategy wait on a Clock when used by a Policy
+// created WithClock, or when bound to it with strategy.Strategy.Bind.
+type Clock struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+// NewClock creates a Clock whose time starts at the given time.
+func NewClock(start time.Time) *Clock {
+	return &Clock{now: start}
+}
+
+// Now returns the Clock's current time.
+func (c *Clock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.now
+}
+
+// After advances the Clock by the given duration and returns a channel that
+// has already received the new time.
+func (c *Clock) After(duration time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- c.Advance(duration)
+
+	return ch
+}
+
+// Advance moves the Clock's time forward by the given duration, and returns
+// the new time.
+func (c *Clock) Advance(duration time.Duration) time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.now = c.now.Add(duration)
+
+	return c.now
+}
@@ -0,0 +1,164 @@
+package retrytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Rican7/retry"
+	"github.com/Rican7/retry/backoff"
+	"github.com/Rican7/retry/jitter"
+	"github.com/Rican7/retry/strategy"
+)
+
+// fakeT is a testing.TB that records whether a test error was reported.
+type fakeT struct {
+	testing.TB
+
+	failed bool
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(format string, args ...any) {
+	t.failed = true
+}
+
+func TestFailN(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	action := FailN(2, errFailed)
+
+	for attempt, expected := range []error{errFailed, errFailed, nil, nil} {
+		if err := action(uint(attempt + 1)); err != expected {
+			t.Errorf("expected attempt %d to return %v, received %v instead", attempt+1, expected, err)
+		}
+	}
+}
+
+func TestSequence(t *testing.T) {
+	errFirst := errors.New("first")
+	errSecond := errors.New("second")
+
+	action := Sequence(errFirst, nil, errSecond)
+
+	for attempt, expected := range []error{errFirst, nil, errSecond, errSecond} {
+		if err := action(uint(attempt + 1)); err != expected {
+			t.Errorf("expected attempt %d to return %v, received %v instead", attempt+1, expected, err)
+		}
+	}
+
+	if err := Sequence()(1); err != nil {
+		t.Errorf("expected a nil error, received %q instead", err)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	clock := NewClock(time.Unix(0, 0))
+	recorder := NewRecorder(clock)
+
+	err := retry.Retry(
+		recorder.Record(FailN(3, errors.New("failed"))),
+		strategy.Backoff(backoff.Linear(time.Second)).Bind(context.Background(), clock),
+	)
+
+	if err != nil {
+		t.Errorf("expected a nil error, received %q instead", err)
+	}
+
+	recorder.AssertAttempts(t, 4)
+	recorder.AssertDelays(t, time.Second, 2*time.Second, 3*time.Second)
+
+	for i, call := range recorder.Calls() {
+		if call.Attempt != uint(i+1) {
+			t.Errorf("expected attempt number %d, received %d instead", i+1, call.Attempt)
+		}
+	}
+}
+
+func TestRecorderHook(t *testing.T) {
+	clock := NewClock(time.Unix(0, 0))
+	recorder := NewRecorder(nil)
+
+	policy := retry.NewPolicy(
+		retry.WithStrategies(strategy.Limit(3), strategy.Wait(time.Minute, time.Hour)),
+		retry.WithClock(clock),
+		retry.WithHooks(recorder.Hook()),
+	)
+
+	policy.Do(FailN(5, errors.New("failed")))
+
+	recorder.AssertAttempts(t, 3)
+	recorder.AssertDelays(t, time.Minute, time.Hour)
+}
+
+func TestRecorderAssertionFailures(t *testing.T) {
+	clock := NewClock(time.Unix(0, 0))
+	recorder := NewRecorder(clock)
+
+	action := recorder.Record(Sequence(nil))
+	action(1)
+	clock.Advance(time.Second)
+	action(2)
+
+	tests := []func(t testing.TB){
+		func(t testing.TB) { recorder.AssertAttempts(t, 3) },
+		func(t testing.TB) { recorder.AssertDelays(t) },
+		func(t testing.TB) { recorder.AssertDelays(t, time.Minute) },
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			fake := &fakeT{TB: t}
+
+			test(fake)
+
+			if !fake.failed {
+				t.Error("expected the assertion to fail")
+			}
+		})
+	}
+}
+
+func TestClock(t *testing.T) {
+	start := time.Unix(0, 0)
+	clock := NewClock(start)
+
+	if now := clock.Now(); !now.Equal(start) {
+		t.Errorf("expected the time %s, received %s instead", start, now)
+	}
+
+	if now := <-clock.After(time.Hour); !now.Equal(start.Add(time.Hour)) {
+		t.Errorf("expected the time %s, received %s instead", start.Add(time.Hour), now)
+	}
+
+	if now := clock.Advance(time.Minute); !now.Equal(clock.Now()) {
+		t.Errorf("expected the advanced time %s, received %s instead", clock.Now(), now)
+	}
+}
+
+func TestClockStrategiesRunInstantly(t *testing.T) {
+	clock := NewClock(time.Unix(0, 0))
+
+	start := time.Now()
+
+	policy := retry.NewPolicy(
+		retry.WithStrategies(
+			strategy.Wait(time.Hour),
+			strategy.BackoffWithJitter(backoff.Exponential(time.Hour, 2), jitter.Equal(nil)),
+		),
+		retry.WithClock(clock),
+	)
+
+	policy.Do(FailN(10, errors.New("failed")))
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected retrying to be instant, but it took %s", elapsed)
+	}
+
+	if clock.Now().Sub(time.Unix(0, 0)) < 10*time.Hour {
+		t.Errorf("expected the clock to advance, but it's at %s", clock.Now())
+	}
+}
@@ -0,0 +1,162 @@
+package strategy
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Rican7/retry/clock"
+	"github.com/Rican7/retry/internal/describe"
+)
+
+// Adaptive is a controller that adapts a delay to the outcomes of attempts, as
+// observed across every retry process that shares it, in the style of
+// additive-increase/multiplicative-decrease (AIMD) congestion control.
+//
+// Each observed failure multiplies the delay by a factor (lengthening it
+// quickly as failures rise), while each observed success subtracts a fixed step
+// from it (shortening it gradually as successes come back). So that old
+// failures age out, the delay is also shortened by a step for every window of
+// time that passes without an outcome being observed. It's safe for concurrent
+// use.
+type Adaptive struct {
+	mutex   sync.Mutex
+	clock   clock.Clock
+	initial time.Duration
+	max     time.Duration
+	factor  float64
+	window  time.Duration
+	delay   time.Duration
+	updated time.Time
+}
+
+// NewAdaptive creates an Adaptive controller with no delay.
+//
+// The first failure sets the delay to the given initial duration, and every
+// failure after that multiplies the delay by the given factor, up to the given
+// max duration. Each success, and each window of the given duration that passes
+// without a success or failure, decreases the delay by the initial duration,
+// down to no delay. A non-positive window never decreases the delay over time.
+//
+// The given clock is what time passes on, and what is used to wait. If a nil
+// clock is passed, time passes on the system clock, and the clock that the
+// strategy is bound to (see Strategy.Bind) is used to wait, which is the system
+// clock by default.
+func NewAdaptive(initial, max time.Duration, factor float64, window time.Duration, c clock.Clock) *Adaptive {
+	a := &Adaptive{
+		clock:   c,
+		initial: initial,
+		max:     max,
+		factor:  factor,
+		window:  window,
+	}
+
+	a.updated = a.now()
+
+	return a
+}
+
+// Delay returns the current delay.
+func (a *Adaptive) Delay() time.Duration {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.decay()
+
+	return a.delay
+}
+
+// Success records a successful attempt, decreasing the delay.
+func (a *Adaptive) Success() {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.decay()
+	a.decrease(1)
+	a.updated = a.now()
+}
+
+// Failure records a failed attempt, increasing the delay.
+func (a *Adaptive) Failure() {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.decay()
+
+	delay := float64(a.initial)
+
+	if a.delay >= a.initial {
+		delay = float64(a.delay) * a.factor
+	}
+
+	// Compare as floats, to avoid overflowing the duration
+	if delay > float64(a.max) {
+		a.delay = a.max
+	} else {
+		a.delay = time.Duration(delay)
+	}
+
+	a.updated = a.now()
+}
+
+// Strategy creates a Strategy that waits before each attempt after the first,
+// with a duration of the controller's current delay.
+//
+// As a Strategy is only evaluated for an attempt after the first once the
+// previous attempt has failed, the Strategy records that failure before
+// waiting. Successes may only be recorded by the caller, by calling Success once
+// an attempt succeeds, such as with the hook created by retry.AdaptiveHook.
+func (a *Adaptive) Strategy() Strategy {
+	return newDescribedStrategy(func(ctx context.Context, c clock.Clock, attempt uint) bool {
+		if a.clock != nil {
+			c = a.clock
+		}
+
+		if attempt > 0 {
+			a.Failure()
+
+			return wait(ctx, c, a.Delay())
+		}
+
+		return true
+	}, describe.Constructor{
+		Name:   "NewAdaptive",
+		Args:   []any{a.initial, a.max, a.factor, a.window},
+		Method: "Strategy",
+	})
+}
+
+// decay decreases the delay by the initial duration for every window that's
+// passed since the delay was last updated.
+func (a *Adaptive) decay() {
+	if a.window <= 0 {
+		return
+	}
+
+	windows := a.now().Sub(a.updated) / a.window
+
+	if windows > 0 {
+		a.decrease(int64(windows))
+		a.updated = a.updated.Add(windows * a.window)
+	}
+}
+
+// decrease decreases the delay by the initial duration the given number of
+// times, down to no delay.
+func (a *Adaptive) decrease(times int64) {
+	// Compare as floats, to avoid overflowing the duration
+	if float64(times)*float64(a.initial) >= float64(a.delay) {
+		a.delay = 0
+	} else {
+		a.delay -= time.Duration(times) * a.initial
+	}
+}
+
+// now returns the current time, according to the controller's clock.
+func (a *Adaptive) now() time.Time {
+	if a.clock == nil {
+		return time.Now()
+	}
+
+	return a.clock.Now()
+}
@@ -0,0 +1,165 @@
+package strategy_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Rican7/retry"
+	"github.com/Rican7/retry/retrytest"
+	"github.com/Rican7/retry/strategy"
+)
+
+func TestAdaptive(t *testing.T) {
+	const initial = 10 * time.Millisecond
+	const max = 100 * time.Millisecond
+	const factor = 2
+
+	controller := strategy.NewAdaptive(initial, max, factor, 0, retrytest.NewClock(time.Now()))
+
+	if delay := controller.Delay(); delay != 0 {
+		t.Errorf("expected no delay, received %s instead", delay)
+	}
+
+	// Failures multiplicatively increase the delay, up to the max
+	for _, expected := range []time.Duration{10, 20, 40, 80, 100, 100} {
+		controller.Failure()
+
+		if delay := controller.Delay(); delay != expected*time.Millisecond {
+			t.Errorf("expected a delay of %s, received %s instead", expected*time.Millisecond, delay)
+		}
+	}
+
+	// Successes additively decrease the delay, down to none
+	for _, expected := range []time.Duration{90, 80, 70, 60, 50, 40, 30, 20, 10, 0, 0} {
+		controller.Success()
+
+		if delay := controller.Delay(); delay != expected*time.Millisecond {
+			t.Errorf("expected a delay of %s, received %s instead", expected*time.Millisecond, delay)
+		}
+	}
+}
+
+func TestAdaptiveOverflow(t *testing.T) {
+	const max = time.Duration(1<<63 - 1)
+
+	controller := strategy.NewAdaptive(time.Hour, max, 1e6, 0, retrytest.NewClock(time.Now()))
+
+	for i := 0; i < 10; i++ {
+		controller.Failure()
+	}
+
+	if delay := controller.Delay(); delay != max {
+		t.Errorf("expected a delay of %s, received %s instead", time.Duration(max), delay)
+	}
+}
+
+func TestAdaptiveDecay(t *testing.T) {
+	const initial = time.Second
+	const window = time.Minute
+
+	clock := retrytest.NewClock(time.Now())
+	controller := strategy.NewAdaptive(initial, time.Hour, 2, window, clock)
+
+	for i := 0; i < 4; i++ {
+		controller.Failure()
+	}
+
+	steps := []struct {
+		advance time.Duration
+		delay   time.Duration
+	}{
+		{0, 8 * initial},
+		{window / 2, 8 * initial},
+		{window / 2, 7 * initial},
+		{2 * window, 5 * initial},
+		{10 * window, 0},
+	}
+
+	// Old failures age out, one step per window that passes
+	for _, step := range steps {
+		clock.Advance(step.advance)
+
+		if delay := controller.Delay(); delay != step.delay {
+			t.Errorf("expected a delay of %s, received %s instead", step.delay, delay)
+		}
+	}
+}
+
+func TestAdaptiveStrategy(t *testing.T) {
+	const initial = time.Second
+
+	start := time.Now()
+	clock := retrytest.NewClock(start)
+	controller := strategy.NewAdaptive(initial, time.Minute, 3, 0, clock)
+	adaptive := controller.Strategy()
+
+	if !adaptive(0) {
+		t.Error("strategy expected to return true")
+	}
+
+	if delay := controller.Delay(); delay != 0 {
+		t.Errorf("expected no delay, received %s instead", delay)
+	}
+
+	// Each attempt after the first follows a failure, which is recorded
+	if !adaptive(1) || !adaptive(2) {
+		t.Error("strategy expected to return true")
+	}
+
+	if waited, expected := clock.Now().Sub(start), initial+3*initial; waited != expected {
+		t.Errorf("expected to wait %s, but waited %s instead", expected, waited)
+	}
+}
+
+func TestAdaptiveHook(t *testing.T) {
+	const initial = time.Second
+
+	clock := retrytest.NewClock(time.Now())
+	controller := strategy.NewAdaptive(initial, time.Minute, 2, 0, clock)
+
+	policy := retry.NewPolicy(
+		retry.WithClock(clock),
+		retry.WithStrategies(controller.Strategy()),
+		retry.WithHooks(retry.AdaptiveHook(controller)),
+	)
+
+	// Two failures, then a success
+	err := policy.Do(retrytest.FailN(2, errors.New("erroring")))
+
+	if err != nil {
+		t.Errorf("expected a nil error, received %q instead", err)
+	}
+
+	// The last failure doubled the delay, and the success decreased it
+	if delay := controller.Delay(); delay != initial {
+		t.Errorf("expected a delay of %s, received %s instead", initial, delay)
+	}
+}
+
+func TestAdaptiveConcurrentUse(t *testing.T) {
+	const goroutines = 10
+
+	controller := strategy.NewAdaptive(time.Second, time.Minute, 2, time.Second, retrytest.NewClock(time.Now()))
+	adaptive := controller.Strategy()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			adaptive(1)
+			controller.Success()
+		}()
+	}
+
+	wg.Wait()
+
+	if delay := controller.Delay(); delay < 0 || delay > time.Minute {
+		t.Errorf("expected a delay within bounds, received %s instead", delay)
+	}
+}
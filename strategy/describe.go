@@ -0,0 +1,56 @@
+package strategy
+
+import (
+	"context"
+
+	"github.com/Rican7/retry/clock"
+	"github.com/Rican7/retry/internal/describe"
+	"github.com/Rican7/retry/internal/spec"
+)
+
+// evaluator defines a function that evaluates a Strategy created by this
+// package for the given attempt number, waiting on the given clock and halting
+// once the given context is done.
+type evaluator = spec.Evaluator
+
+// newStrategy creates a Strategy that's evaluated by the given evaluator, with
+// a background context and the system clock, and that's described by the given
+// constructor name and arguments.
+func newStrategy(evaluate evaluator, name string, args ...any) Strategy {
+	return newDescribedStrategy(evaluate, describe.Constructor{Name: name, Args: args})
+}
+
+// newDescribedStrategy creates a Strategy that's evaluated by the given
+// evaluator, as newStrategy does, and that's described by the given
+// constructor.
+func newDescribedStrategy(evaluate evaluator, constructor describe.Constructor) Strategy {
+	return spec.Register(func(attempt uint) bool {
+		return evaluate(context.Background(), clock.System(), attempt)
+	}, &spec.Spec{Evaluate: evaluate, Constructor: constructor})
+}
+
+// String describes the Strategy by the call of the constructor that created
+// it, such as "Backoff(Exponential(10ms, 2))". A Strategy that wasn't created by
+// this package is described as "<custom>".
+func (s Strategy) String() string {
+	if s == nil {
+		return "<nil>"
+	}
+
+	described := spec.Of(s)
+
+	if described == nil {
+		return "<custom>"
+	}
+
+	return described.String()
+}
+
+// String describes the FactoryFunc by the Strategy that it creates.
+func (f FactoryFunc) String() string {
+	if f == nil {
+		return "<nil>"
+	}
+
+	return f().String()
+}
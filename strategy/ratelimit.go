@@ -0,0 +1,165 @@
+package strategy
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Rican7/retry/clock"
+	"github.com/Rican7/retry/internal/describe"
+)
+
+// Limiter defines a type that limits the rate of events, by blocking until an
+// event is allowed or the given context is done. A `*rate.Limiter` (from
+// golang.org/x/time/rate) and a *TokenBucket both satisfy this interface.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// RateLimit creates a Strategy that waits for the given Limiter to allow each
+// attempt, including the first. Sharing the Limiter between strategies limits
+// the rate of attempts across all of them.
+//
+// The Limiter is waited on with the context that the Strategy is bound to (see
+// Strategy.Bind), such as the context of a retrying process, so that waiting
+// stops once it's done. If the Limiter fails, the Strategy halts the retrying
+// process.
+func RateLimit(limiter Limiter) Strategy {
+	return newStrategy(rateLimit(context.Background(), limiter), "RateLimit", limiter)
+}
+
+// RateLimitContext creates a Strategy that waits for the given Limiter to allow
+// each attempt, including the first. If the given context, or the context that
+// the Strategy is bound to, is done (or the Limiter otherwise fails) while
+// waiting, the Strategy halts the retrying process.
+func RateLimitContext(ctx context.Context, limiter Limiter) Strategy {
+	return newStrategy(rateLimit(ctx, limiter), "RateLimitContext", limiter)
+}
+
+// rateLimit creates the evaluator of RateLimitContext, which waits with both
+// the given context and the context that it's evaluated with.
+func rateLimit(ctx context.Context, limiter Limiter) evaluator {
+	return func(evaluationCtx context.Context, _ clock.Clock, attempt uint) bool {
+		ctx, cancel := mergeContexts(ctx, evaluationCtx)
+		defer cancel()
+
+		return limiter.Wait(ctx) == nil
+	}
+}
+
+// mergeContexts creates a context that's done once either of the given
+// contexts is done, and that carries the values of the first one.
+func mergeContexts(first, second context.Context) (context.Context, context.CancelFunc) {
+	if second.Done() == nil {
+		return first, func() {}
+	}
+
+	if first.Done() == nil {
+		return second, func() {}
+	}
+
+	ctx, cancel := context.WithCancel(first)
+
+	go func() {
+		select {
+		case <-second.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancel
+}
+
+// TokenBucket is a Limiter that allows events at a steady rate, with bursts of
+// up to a given size. It's safe for concurrent use.
+type TokenBucket struct {
+	mutex    sync.Mutex
+	clock    clock.Clock
+	burst    float64
+	interval time.Duration
+	tokens   float64
+	last     time.Time
+}
+
+// NewTokenBucket creates a TokenBucket that starts full with the given burst of
+// tokens, and that adds a token every given interval.
+//
+// The given clock is what is used to tell and wait on time. If a nil clock is
+// passed, the system clock will be used.
+func NewTokenBucket(burst uint, interval time.Duration, c clock.Clock) *TokenBucket {
+	if c == nil {
+		c = clock.System()
+	}
+
+	return &TokenBucket{
+		clock:    c,
+		burst:    float64(burst),
+		interval: interval,
+		tokens:   float64(burst),
+		last:     c.Now(),
+	}
+}
+
+// String describes the TokenBucket by its burst and interval, such as
+// "TokenBucket(10, 100ms)".
+func (b *TokenBucket) String() string {
+	return describe.Call("TokenBucket", uint(b.burst), b.interval)
+}
+
+// Wait blocks until a token is available and takes it, or until the given
+// context is done, in which case the context's error is returned.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	wait := b.reserve()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	select {
+	case <-b.clock.After(wait):
+		return nil
+	case <-ctx.Done():
+		b.release()
+
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token from the bucket, and returns how long to wait before the
+// token is actually available.
+func (b *TokenBucket) reserve() time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	now := b.clock.Now()
+
+	if b.interval > 0 {
+		b.tokens += float64(now.Sub(b.last)) / float64(b.interval)
+	}
+
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+
+	b.last = now
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens * float64(b.interval))
+}
+
+// release returns a reserved token to the bucket.
+func (b *TokenBucket) release() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.tokens++
+}
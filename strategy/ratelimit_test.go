@@ -0,0 +1,206 @@
+package strategy_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Rican7/retry/retrytest"
+	"github.com/Rican7/retry/strategy"
+)
+
+// blockingClock is a retrytest.Clock whose timers never fire.
+type blockingClock struct {
+	retrytest.Clock
+}
+
+func (*blockingClock) After(duration time.Duration) <-chan time.Time {
+	return nil
+}
+
+// limiterFunc is a Limiter defined by a function.
+type limiterFunc func(ctx context.Context) error
+
+func (f limiterFunc) Wait(ctx context.Context) error {
+	return f(ctx)
+}
+
+func TestRateLimit(t *testing.T) {
+	var waits int
+
+	limiter := limiterFunc(func(ctx context.Context) error {
+		waits++
+
+		if waits > 2 {
+			return errors.New("limit exceeded")
+		}
+
+		return nil
+	})
+
+	strategy := strategy.RateLimit(limiter)
+
+	if !strategy(0) || !strategy(1) {
+		t.Error("strategy expected to return true")
+	}
+
+	if strategy(2) {
+		t.Error("strategy expected to return false")
+	}
+}
+
+func TestRateLimitContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	strategy := strategy.RateLimitContext(ctx, strategy.NewTokenBucket(0, time.Second, &blockingClock{}))
+
+	cancel()
+
+	if strategy(0) {
+		t.Error("strategy expected to return false")
+	}
+}
+
+func TestRateLimitBound(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	strategies := map[string]strategy.Strategy{
+		"RateLimit":        strategy.RateLimit(strategy.NewTokenBucket(0, time.Second, &blockingClock{})),
+		"RateLimitContext": strategy.RateLimitContext(context.Background(), strategy.NewTokenBucket(0, time.Second, &blockingClock{})),
+	}
+
+	for name, strategy := range strategies {
+		if strategy.Bind(ctx, nil)(0) {
+			t.Errorf("%s strategy expected to return false", name)
+		}
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	const burst = 2
+	const interval = time.Second
+
+	clock := retrytest.NewClock(time.Now())
+	bucket := strategy.NewTokenBucket(burst, interval, clock)
+
+	// The burst is immediate, then each wait is an interval after the last
+	expectedWaits := []time.Duration{0, 0, interval, interval, interval}
+
+	for _, expected := range expectedWaits {
+		start := clock.Now()
+
+		if err := bucket.Wait(context.Background()); err != nil {
+			t.Errorf("expected a nil error, received %q instead", err)
+		}
+
+		if waited := clock.Now().Sub(start); waited != expected {
+			t.Errorf("expected a wait of %s, received %s instead", expected, waited)
+		}
+	}
+}
+
+func TestTokenBucketRefills(t *testing.T) {
+	const burst = 3
+	const interval = time.Second
+
+	clock := retrytest.NewClock(time.Now())
+	bucket := strategy.NewTokenBucket(burst, interval, clock)
+
+	for i := 0; i < burst; i++ {
+		bucket.Wait(context.Background())
+	}
+
+	// Let more time pass than is needed to refill the bucket
+	start := clock.Advance(10 * interval)
+
+	for i := 0; i < burst; i++ {
+		bucket.Wait(context.Background())
+	}
+
+	if waited := clock.Now().Sub(start); waited != 0 {
+		t.Errorf("expected no waits, but waited %s", waited)
+	}
+}
+
+func TestTokenBucketContextDone(t *testing.T) {
+	const interval = time.Second
+
+	clock := &blockingClock{}
+	bucket := strategy.NewTokenBucket(1, interval, clock)
+
+	if err := bucket.Wait(context.Background()); err != nil {
+		t.Errorf("expected a nil error, received %q instead", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeMarginOfError)
+	defer cancel()
+
+	if err := bucket.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected a deadline exceeded error, received %q instead", err)
+	}
+
+	if err := bucket.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected a deadline exceeded error, received %q instead", err)
+	}
+
+	// The cancelled reservation's token is returned, so a single interval
+	// refills the bucket
+	clock.Advance(interval)
+
+	ctx, cancel = context.WithTimeout(context.Background(), timeMarginOfError)
+	defer cancel()
+
+	if err := bucket.Wait(ctx); err != nil {
+		t.Errorf("expected a nil error, received %q instead", err)
+	}
+}
+
+func TestTokenBucketConcurrentUse(t *testing.T) {
+	const goroutines = 10
+	const burst = 5
+	const interval = time.Second
+
+	clock := retrytest.NewClock(time.Now())
+	bucket := strategy.NewTokenBucket(burst, interval, clock)
+	start := clock.Now()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if err := bucket.Wait(context.Background()); err != nil {
+				t.Errorf("expected a nil error, received %q instead", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	// The burst is immediate, and waiting (and therefore refilling)
+	// concurrently only shortens the waits for the rest, which are at most an
+	// interval longer than the last
+	var expected time.Duration
+
+	for i := 1; i <= goroutines-burst; i++ {
+		expected += time.Duration(i) * interval
+	}
+
+	if waited := clock.Now().Sub(start); waited > expected {
+		t.Errorf("expected to wait at most %s, but waited %s instead", expected, waited)
+	}
+}
+
+func TestTokenBucketSystemClock(t *testing.T) {
+	bucket := strategy.NewTokenBucket(1, timeMarginOfError, nil)
+
+	if now := time.Now(); bucket.Wait(context.Background()) != nil || timeMarginOfError < time.Since(now) {
+		t.Error("expected a token to be available in ~0 time")
+	}
+}
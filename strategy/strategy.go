@@ -4,9 +4,13 @@
 package strategy
 
 import (
+	"context"
 	"time"
 
 	"github.com/Rican7/retry/backoff"
+	"github.com/Rican7/retry/clock"
+	"github.com/Rican7/retry/internal/describe"
+	"github.com/Rican7/retry/internal/spec"
 	"github.com/Rican7/retry/jitter"
 )
 
@@ -20,20 +24,79 @@ import (
 // made. This allows for a pre-action, such as a delay, etc.
 type Strategy func(attempt uint) bool
 
+// Factory defines a type that creates a new Strategy for every invocation of a
+// retry process, so that strategies that hold state between attempts (such as a
+// previous delay, a start time, or a budget) may be defined once and reused
+// without leaking that state across invocations.
+type Factory interface {
+	New() Strategy
+}
+
+// FactoryFunc defines a function that creates a new Strategy, allowing for an
+// ordinary function to be used as a Factory.
+type FactoryFunc func() Strategy
+
+// New creates a new Strategy by calling the function.
+func (f FactoryFunc) New() Strategy {
+	return f()
+}
+
+// Bind creates a Strategy that evaluates the Strategy with the given context
+// and clock. A Strategy created by this package waits on the given clock, rather
+// than sleeping, and halts the retrying process (returning `false`) as soon as
+// the given context is done, even while waiting. If a nil clock is passed, the
+// system clock will be used.
+//
+// A bound Strategy keeps the context and clock that it was first bound to. A
+// Strategy that wasn't created by this package is returned as is.
+func (s Strategy) Bind(ctx context.Context, c clock.Clock) Strategy {
+	described := spec.Of(s)
+
+	if described == nil {
+		return s
+	}
+
+	if c == nil {
+		c = clock.System()
+	}
+
+	return newStrategy(func(_ context.Context, _ clock.Clock, attempt uint) bool {
+		return described.Evaluate(ctx, c, attempt)
+	}, described.Name, described.Args...)
+}
+
+// New returns the Strategy itself, allowing for any Strategy to be used as a
+// Factory. As such, a Strategy used this way is shared across invocations.
+func (s Strategy) New() Strategy {
+	return s
+}
+
 // Limit creates a Strategy that limits the number of attempts that Retry will
 // make.
 func Limit(attemptLimit uint) Strategy {
-	return func(attempt uint) bool {
+	return newStrategy(func(_ context.Context, _ clock.Clock, attempt uint) bool {
 		return (attempt < attemptLimit)
-	}
+	}, "Limit", attemptLimit)
 }
 
 // Delay creates a Strategy that waits the given duration before the first
 // attempt is made.
 func Delay(duration time.Duration) Strategy {
-	return func(attempt uint) bool {
+	return newStrategy(delayWithJitter(duration, jitter.None()), "Delay", duration)
+}
+
+// DelayWithJitter creates a Strategy that waits before the first attempt is
+// made, with a duration as defined by the given duration and
+// jitter.Transformation.
+func DelayWithJitter(duration time.Duration, transformation jitter.Transformation) Strategy {
+	return newStrategy(delayWithJitter(duration, transformation), "DelayWithJitter", duration, transformation)
+}
+
+// delayWithJitter creates the evaluator of DelayWithJitter.
+func delayWithJitter(duration time.Duration, transformation jitter.Transformation) evaluator {
+	return func(ctx context.Context, c clock.Clock, attempt uint) bool {
 		if attempt == 0 {
-			time.Sleep(duration)
+			return wait(ctx, c, transformation(duration))
 		}
 
 		return true
@@ -44,7 +107,22 @@ func Delay(duration time.Duration) Strategy {
 // the first. If the number of attempts is greater than the number of durations
 // provided, then the strategy uses the last duration provided.
 func Wait(durations ...time.Duration) Strategy {
-	return func(attempt uint) bool {
+	return newStrategy(waitWithJitter(jitter.None(), durations), "Wait", describe.Args(durations)...)
+}
+
+// WaitWithJitter creates a Strategy that waits for each attempt after the
+// first, with durations as defined by the given durations and
+// jitter.Transformation. If the number of attempts is greater than the number
+// of durations provided, then the strategy uses the last duration provided.
+func WaitWithJitter(transformation jitter.Transformation, durations ...time.Duration) Strategy {
+	args := append([]any{transformation}, describe.Args(durations)...)
+
+	return newStrategy(waitWithJitter(transformation, durations), "WaitWithJitter", args...)
+}
+
+// waitWithJitter creates the evaluator of WaitWithJitter.
+func waitWithJitter(transformation jitter.Transformation, durations []time.Duration) evaluator {
+	return func(ctx context.Context, c clock.Clock, attempt uint) bool {
 		if attempt > 0 && len(durations) > 0 {
 			durationIndex := int(attempt - 1)
 
@@ -52,34 +130,68 @@ func Wait(durations ...time.Duration) Strategy {
 				durationIndex = len(durations) - 1
 			}
 
-			time.Sleep(durations[durationIndex])
+			return wait(ctx, c, transformation(durations[durationIndex]))
 		}
 
 		return true
 	}
 }
 
+// Deadline creates a Strategy that halts the retrying process once the given
+// deadline has passed. As strategies are evaluated in order, it should follow
+// any strategies that wait, so that the deadline is checked after waiting.
+func Deadline(deadline time.Time) Strategy {
+	return newStrategy(func(_ context.Context, c clock.Clock, attempt uint) bool {
+		return c.Now().Before(deadline)
+	}, "Deadline", deadline.Round(0))
+}
+
+// Deadline returns the deadline of a Strategy created by Deadline, with ok set
+// to `true`. For any other Strategy, ok is `false`.
+func (s Strategy) Deadline() (deadline time.Time, ok bool) {
+	described := spec.Of(s)
+
+	if described == nil || described.Name != "Deadline" {
+		return time.Time{}, false
+	}
+
+	return described.Args[0].(time.Time), true
+}
+
 // Backoff creates a Strategy that waits before each attempt, with a duration as
 // defined by the given backoff.Algorithm.
 func Backoff(algorithm backoff.Algorithm) Strategy {
-	return BackoffWithJitter(algorithm, noJitter())
+	return newStrategy(backoffWithJitter(algorithm, jitter.None()), "Backoff", algorithm)
 }
 
 // BackoffWithJitter creates a Strategy that waits before each attempt, with a
 // duration as defined by the given backoff.Algorithm and jitter.Transformation.
 func BackoffWithJitter(algorithm backoff.Algorithm, transformation jitter.Transformation) Strategy {
-	return func(attempt uint) bool {
+	return newStrategy(backoffWithJitter(algorithm, transformation), "BackoffWithJitter", algorithm, transformation)
+}
+
+// backoffWithJitter creates the evaluator of BackoffWithJitter.
+func backoffWithJitter(algorithm backoff.Algorithm, transformation jitter.Transformation) evaluator {
+	return func(ctx context.Context, c clock.Clock, attempt uint) bool {
 		if attempt > 0 {
-			time.Sleep(transformation(algorithm(attempt)))
+			return wait(ctx, c, transformation(algorithm(attempt)))
 		}
 
 		return true
 	}
 }
 
-// noJitter creates a jitter.Transformation that simply returns the input.
-func noJitter() jitter.Transformation {
-	return func(duration time.Duration) time.Duration {
-		return duration
+// wait waits for the given duration on the given clock, and reports whether it
+// did so before the given context was done.
+func wait(ctx context.Context, c clock.Clock, duration time.Duration) bool {
+	if duration <= 0 {
+		return true
+	}
+
+	select {
+	case <-c.After(duration):
+		return true
+	case <-ctx.Done():
+		return false
 	}
 }
@@ -1,8 +1,15 @@
-package strategy
+package strategy_test
 
 import (
+	"context"
+	"math"
 	"testing"
 	"time"
+
+	"github.com/Rican7/retry/backoff"
+	"github.com/Rican7/retry/jitter"
+	"github.com/Rican7/retry/retrytest"
+	"github.com/Rican7/retry/strategy"
 )
 
 // timeMarginOfError represents the acceptable amount of time that may pass for
@@ -14,7 +21,7 @@ func TestLimit(t *testing.T) {
 	// Treat this functionally as n+1.
 	const attemptLimit = 3
 
-	strategy := Limit(attemptLimit)
+	strategy := strategy.Limit(attemptLimit)
 
 	if !strategy(0) {
 		t.Error("strategy expected to return true")
@@ -36,7 +43,7 @@ func TestLimit(t *testing.T) {
 func TestDelay(t *testing.T) {
 	const delayDuration = 10 * timeMarginOfError
 
-	strategy := Delay(delayDuration)
+	strategy := strategy.Delay(delayDuration)
 
 	if now := time.Now(); !strategy(0) || delayDuration > time.Since(now) {
 		t.Errorf(
@@ -51,7 +58,7 @@ func TestDelay(t *testing.T) {
 }
 
 func TestWait(t *testing.T) {
-	strategy := Wait()
+	strategy := strategy.Wait()
 
 	if now := time.Now(); !strategy(0) || timeMarginOfError < time.Since(now) {
 		t.Error("strategy expected to return true in ~0 time")
@@ -65,7 +72,7 @@ func TestWait(t *testing.T) {
 func TestWaitWithDuration(t *testing.T) {
 	const waitDuration = 10 * timeMarginOfError
 
-	strategy := Wait(waitDuration)
+	strategy := strategy.Wait(waitDuration)
 
 	if now := time.Now(); !strategy(0) || timeMarginOfError < time.Since(now) {
 		t.Error("strategy expected to return true in ~0 time")
@@ -87,7 +94,7 @@ func TestWaitWithMultipleDurations(t *testing.T) {
 		40 * timeMarginOfError,
 	}
 
-	strategy := Wait(waitDurations...)
+	strategy := strategy.Wait(waitDurations...)
 
 	if now := time.Now(); !strategy(0) || timeMarginOfError < time.Since(now) {
 		t.Error("strategy expected to return true in ~0 time")
@@ -115,6 +122,79 @@ func TestWaitWithMultipleDurations(t *testing.T) {
 	}
 }
 
+func TestDelayWithJitter(t *testing.T) {
+	const delayDuration = 20 * timeMarginOfError
+
+	transformation := func(duration time.Duration) time.Duration {
+		return duration / 2
+	}
+
+	strategy := strategy.DelayWithJitter(delayDuration, transformation)
+
+	if now := time.Now(); !strategy(0) || transformation(delayDuration) > time.Since(now) || delayDuration < time.Since(now) {
+		t.Errorf(
+			"strategy expected to return true in %s",
+			transformation(delayDuration),
+		)
+	}
+
+	if now := time.Now(); !strategy(5) || timeMarginOfError < time.Since(now) {
+		t.Error("strategy expected to return true in ~0 time")
+	}
+}
+
+func TestWaitWithJitter(t *testing.T) {
+	waitDurations := []time.Duration{
+		20 * timeMarginOfError,
+		40 * timeMarginOfError,
+	}
+
+	var transformed []time.Duration
+
+	transformation := func(duration time.Duration) time.Duration {
+		transformed = append(transformed, duration)
+
+		return duration / 2
+	}
+
+	strategy := strategy.WaitWithJitter(transformation, waitDurations...)
+
+	if now := time.Now(); !strategy(0) || timeMarginOfError < time.Since(now) {
+		t.Error("strategy expected to return true in ~0 time")
+	}
+
+	for i, waitDuration := range append(waitDurations, waitDurations[len(waitDurations)-1]) {
+		expectedResult := waitDuration / 2
+
+		if now := time.Now(); !strategy(uint(i+1)) || expectedResult > time.Since(now) || waitDuration < time.Since(now) {
+			t.Errorf(
+				"strategy expected to return true in %s",
+				expectedResult,
+			)
+		}
+	}
+
+	if len(transformed) != 3 || transformed[0] != waitDurations[0] || transformed[2] != waitDurations[1] {
+		t.Errorf("transformation expected to be applied to each wait duration, received %v instead", transformed)
+	}
+}
+
+func TestDeadline(t *testing.T) {
+	const deadlineDuration = 10 * timeMarginOfError
+
+	strategy := strategy.Deadline(time.Now().Add(deadlineDuration))
+
+	if !strategy(0) {
+		t.Error("strategy expected to return true")
+	}
+
+	time.Sleep(deadlineDuration)
+
+	if strategy(1) {
+		t.Error("strategy expected to return false")
+	}
+}
+
 func TestBackoff(t *testing.T) {
 	const testCycles = 10
 	const backoffDuration = testCycles * timeMarginOfError
@@ -124,7 +204,7 @@ func TestBackoff(t *testing.T) {
 		return backoffDuration - (time.Duration(attempt) * algorithmDurationBase)
 	}
 
-	strategy := Backoff(algorithm)
+	strategy := strategy.Backoff(algorithm)
 
 	if now := time.Now(); !strategy(0) || timeMarginOfError < time.Since(now) {
 		t.Error("strategy expected to return true in ~0 time")
@@ -162,7 +242,7 @@ func TestBackoffWithJitter(t *testing.T) {
 		return duration - (backoffDuration / 2)
 	}
 
-	strategy := BackoffWithJitter(algorithm, transformation)
+	strategy := strategy.BackoffWithJitter(algorithm, transformation)
 
 	if now := time.Now(); !strategy(0) || timeMarginOfError < time.Since(now) {
 		t.Error("strategy expected to return true in ~0 time")
@@ -187,16 +267,198 @@ func TestBackoffWithJitter(t *testing.T) {
 	}
 }
 
-func TestNoJitter(t *testing.T) {
-	transformation := noJitter()
+func TestStrategyNew(t *testing.T) {
+	const attemptLimit = 3
+
+	strategy := strategy.Limit(attemptLimit).New()
+
+	if !strategy(attemptLimit - 1) {
+		t.Error("strategy expected to return true")
+	}
+
+	if strategy(attemptLimit) {
+		t.Error("strategy expected to return false")
+	}
+}
 
-	for i := uint(0); i < 10; i++ {
-		duration := time.Duration(i) * timeMarginOfError
-		result := transformation(duration)
-		expected := duration
+func TestFactoryFunc(t *testing.T) {
+	const budget = 2
 
-		if result != expected {
-			t.Errorf("transformation expected to return a %s duration, but received %s instead", expected, result)
+	var factory strategy.Factory = strategy.FactoryFunc(func() strategy.Strategy {
+		remaining := budget
+
+		return func(attempt uint) bool {
+			remaining--
+
+			return remaining >= 0
+		}
+	})
+
+	first := factory.New()
+	second := factory.New()
+
+	for i := uint(0); i < budget; i++ {
+		if !first(i) {
+			t.Error("strategy expected to return true")
 		}
 	}
+
+	if first(budget) {
+		t.Error("strategy expected to return false")
+	}
+
+	if !second(0) {
+		t.Error("new strategy expected to not share state")
+	}
+}
+
+// namedStrategy is a named (non-closure) Strategy function.
+func namedStrategy(attempt uint) bool {
+	return true
+}
+
+func TestStrategyString(t *testing.T) {
+	const duration = 10 * time.Millisecond
+
+	deadline := time.Date(2026, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	strategies := map[string]strategy.Strategy{
+		"Limit(5)":                                  strategy.Limit(5),
+		"Delay(10ms)":                               strategy.Delay(duration),
+		"DelayWithJitter(10ms, Full)":               strategy.DelayWithJitter(duration, jitter.Full(nil)),
+		"Wait(10ms, 1s)":                            strategy.Wait(duration, time.Second),
+		"WaitWithJitter(Deviation(0.5), 10ms)":      strategy.WaitWithJitter(jitter.Deviation(nil, 0.5), duration),
+		"Deadline(2026-01-02 03:04:05 +0000 UTC)":   strategy.Deadline(deadline),
+		"Backoff(Exponential(10ms, 2))":             strategy.Backoff(backoff.Exponential(duration, 2)),
+		"BackoffWithJitter(Linear(10ms), Equal)":    strategy.BackoffWithJitter(backoff.Linear(duration), jitter.Equal(nil)),
+		"RateLimit(TokenBucket(10, 10ms))":          strategy.RateLimit(strategy.NewTokenBucket(10, duration, nil)),
+		"RateLimitContext(TokenBucket(1, 1s))":      strategy.RateLimitContext(context.Background(), strategy.NewTokenBucket(1, time.Second, nil)),
+		"NewAdaptive(10ms, 1s, 2, 1m0s).Strategy()": strategy.NewAdaptive(duration, time.Second, 2, time.Minute, nil).Strategy(),
+		"<custom>": namedStrategy,
+		"<nil>":    nil,
+	}
+
+	for expected, strategy := range strategies {
+		if description := strategy.String(); description != expected {
+			t.Errorf("expected the description %q, received %q instead", expected, description)
+		}
+	}
+
+	if description := strategy.Strategy(func(attempt uint) bool { return true }).String(); description != "<custom>" {
+		t.Errorf("expected the description %q, received %q instead", "<custom>", description)
+	}
+}
+
+func TestStrategyStringDoesNotAffectEvaluation(t *testing.T) {
+	strategy := strategy.Limit(3)
+
+	_ = strategy.String()
+
+	if strategy(math.MaxUint) {
+		t.Error("strategy expected to return false")
+	}
+}
+
+func TestFactoryFuncString(t *testing.T) {
+	factory := strategy.FactoryFunc(func() strategy.Strategy {
+		return strategy.Limit(1)
+	})
+
+	if expected := "Limit(1)"; factory.String() != expected {
+		t.Errorf("expected the description %q, received %q instead", expected, factory.String())
+	}
+}
+
+func TestBind(t *testing.T) {
+	start := time.Now()
+	clock := retrytest.NewClock(start)
+
+	strategy := strategy.Wait(time.Hour).Bind(context.Background(), clock)
+
+	if !strategy(0) || !strategy(1) {
+		t.Error("strategy expected to return true")
+	}
+
+	if waited := clock.Now().Sub(start); waited != time.Hour {
+		t.Errorf("expected to wait %s, but waited %s instead", time.Hour, waited)
+	}
+
+	if expected := "Wait(1h0m0s)"; strategy.String() != expected {
+		t.Errorf("expected the description %q, received %q instead", expected, strategy.String())
+	}
+}
+
+func TestBindDoneContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	controller := strategy.NewAdaptive(time.Hour, time.Hour, 1, 0, nil)
+	controller.Failure()
+
+	strategies := []strategy.Strategy{
+		strategy.Delay(time.Hour),
+		strategy.Wait(time.Hour),
+		strategy.Backoff(backoff.Incremental(time.Hour, 0)),
+		strategy.BackoffWithJitter(backoff.Incremental(time.Hour, 0), jitter.None()),
+		controller.Strategy(),
+	}
+
+	for _, strategy := range strategies {
+		bound := strategy.Bind(ctx, &blockingClock{})
+
+		if bound(0) && bound(1) {
+			t.Errorf("strategy %s expected to return false", strategy)
+		}
+	}
+}
+
+func TestBindDeadline(t *testing.T) {
+	clock := retrytest.NewClock(time.Now())
+
+	strategy := strategy.Deadline(clock.Now().Add(time.Minute)).Bind(context.Background(), clock)
+
+	if !strategy(0) {
+		t.Error("strategy expected to return true")
+	}
+
+	clock.Advance(time.Minute)
+
+	if strategy(1) {
+		t.Error("strategy expected to return false")
+	}
+}
+
+func TestStrategyDeadline(t *testing.T) {
+	expected := time.Date(2026, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	strategies := map[string]strategy.Strategy{
+		"unbound": strategy.Deadline(expected),
+		"bound":   strategy.Deadline(expected).Bind(context.Background(), nil),
+	}
+
+	for name, strategy := range strategies {
+		if deadline, ok := strategy.Deadline(); !ok || !deadline.Equal(expected) {
+			t.Errorf("expected the %s strategy to have the deadline %s, received %s instead", name, expected, deadline)
+		}
+	}
+
+	for _, strategy := range []strategy.Strategy{strategy.Limit(1), namedStrategy, nil} {
+		if _, ok := strategy.Deadline(); ok {
+			t.Errorf("expected the strategy %s to not have a deadline", strategy)
+		}
+	}
+}
+
+func TestBindCustomStrategy(t *testing.T) {
+	var evaluated bool
+
+	strategy := strategy.Strategy(func(attempt uint) bool {
+		evaluated = true
+
+		return true
+	})
+
+	if !strategy.Bind(context.Background(), nil)(0) || !evaluated {
+		t.Error("custom strategy expected to be evaluated as is")
+	}
 }